@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/client"
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// gps time starts 1980-01-06 (rinex epochs are written in gps time, so no leap seconds are applied)
+var gpsEpoch = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+// Export telemetry to a file
+func runExport(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	kind := fs.String("type", "csv", "csv, rinex or kml")
+	resource := fs.String("resource", "navigation", "table written to csv (navigation or satellite)")
+	out := fs.String("o", "", "output file (default stdout)")
+	rf := addRangeFlags(fs)
+	fs.Parse(args)
+
+	data, err := c.ReadTelemetry(ctx, rf.query())
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return errors.New("no telemetry in the requested range")
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	switch *kind {
+	case "csv":
+		return writeCsv(bw, data, *resource)
+	case "rinex":
+		return writeRinex(bw, data)
+	case "kml":
+		return writeKml(bw, data)
+	default:
+		return fmt.Errorf("unknown export type '%s'", *kind)
+	}
+}
+
+// --- csv ---
+
+// One row per navigation or satellite record, written by the server's csv codec (columns named by their json tags)
+func writeCsv(w io.Writer, data []telemetry.Telemetry, resource string) error {
+	var rows any
+	switch resource {
+	case "navigation":
+		nav := make([]navigation.Navigation, len(data))
+		for i := range data {
+			nav[i] = data[i].Navigation
+		}
+		rows = nav
+	case "satellite":
+		var svs []satellite.Satellite
+		for i := range data {
+			svs = append(svs, data[i].Satellites...)
+		}
+		if len(svs) == 0 {
+			return nil
+		}
+		rows = svs
+	default:
+		return fmt.Errorf("unknown csv resource '%s'", resource)
+	}
+
+	codec, ok := encoder.Lookup("text/csv")
+	if !ok {
+		return errors.New("no csv codec registered")
+	}
+	return codec.Encode(w, rows)
+}
+
+// --- rinex ---
+
+// Rinex 3.04 observation file with pseudorange, carrier phase, doppler and C/No on L1/E1
+func writeRinex(w io.Writer, data []telemetry.Telemetry) error {
+	obs_types := []string{"C1C", "L1C", "D1C", "S1C"}
+
+	// 1. header
+	systems := make(map[byte]bool)
+	for i := range data {
+		for _, sv := range data[i].Satellites {
+			if sys, _, ok := rinexSatellite(sv.PRN); ok {
+				systems[sys] = true
+			}
+		}
+	}
+	n := &data[0].Navigation
+	xyz := geodesy.Lla2Ecef([3]float64{
+		float64(n.Latitude) * geodesy.Deg2Rad, float64(n.Longitude) * geodesy.Deg2Rad, float64(n.Altitude)})
+	first := gpsTime(n.Week, n.ToW)
+
+	rinexLine(w, fmt.Sprintf("%9.2f%11s%-20s%-20s", 3.04, "", "OBSERVATION DATA", "M"), "RINEX VERSION / TYPE")
+	rinexLine(w, fmt.Sprintf("%-20s%-20s%-20s", "sturdr", "", time.Now().UTC().Format("20060102 150405 UTC")), "PGM / RUN BY / DATE")
+	rinexLine(w, "sturdr", "MARKER NAME")
+	rinexLine(w, fmt.Sprintf("%-20s%-40s", "", ""), "OBSERVER / AGENCY")
+	rinexLine(w, fmt.Sprintf("%-20s%-20s%-20s", "", "sturdr", ""), "REC # / TYPE / VERS")
+	rinexLine(w, fmt.Sprintf("%-20s%-20s", "", ""), "ANT # / TYPE")
+	rinexLine(w, fmt.Sprintf("%14.4f%14.4f%14.4f", xyz[0], xyz[1], xyz[2]), "APPROX POSITION XYZ")
+	rinexLine(w, fmt.Sprintf("%14.4f%14.4f%14.4f", 0.0, 0.0, 0.0), "ANTENNA: DELTA H/E/N")
+	for _, sys := range []byte("GERCJI") {
+		if systems[sys] {
+			rinexLine(w, fmt.Sprintf("%c  %3d %s", sys, len(obs_types), strings.Join(obs_types, " ")), "SYS / # / OBS TYPES")
+		}
+	}
+	rinexLine(w, fmt.Sprintf("%6d%6d%6d%6d%6d%13.7f%5s%3s", first.Year(), first.Month(), first.Day(),
+		first.Hour(), first.Minute(), float64(first.Second())+float64(first.Nanosecond())*1e-9, "", "GPS"), "TIME OF FIRST OBS")
+	rinexLine(w, "", "END OF HEADER")
+
+	// 2. observation epochs
+	for i := range data {
+		var lines []string
+		for _, sv := range data[i].Satellites {
+			sys, num, ok := rinexSatellite(sv.PRN)
+			if !ok {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%c%02d%14.3f  %14.3f  %14.3f  %14.3f  ",
+				sys, num, sv.PSR, sv.ADR, sv.Doppler, sv.CNo))
+		}
+		t := gpsTime(data[i].Navigation.Week, data[i].Navigation.ToW)
+		fmt.Fprintf(w, "> %04d %02d %02d %02d %02d%11.7f  0%3d\n", t.Year(), t.Month(), t.Day(),
+			t.Hour(), t.Minute(), float64(t.Second())+float64(t.Nanosecond())*1e-9, len(lines))
+		for _, line := range lines {
+			fmt.Fprintln(w, strings.TrimRight(line, " "))
+		}
+	}
+	return nil
+}
+
+// Write a rinex header line with its label in columns 61-80
+func rinexLine(w io.Writer, content string, label string) {
+	fmt.Fprintf(w, "%-60.60s%-20s\n", content, label)
+}
+
+// Rinex system letter and satellite number of a sturdr prn (same numbering as the gui)
+func rinexSatellite(prn uint8) (byte, int, bool) {
+	id := int(prn)
+	switch {
+	case id <= 31:
+		return 'G', id + 1, true
+	case id >= 32 && id <= 61:
+		return 'E', id - 31, true
+	case id >= 64 && id <= 95:
+		return 'R', id - 63, true
+	case id >= 96 && id <= 158:
+		return 'C', id - 95, true
+	case id >= 159 && id <= 163:
+		return 'J', id - 158, true
+	case id >= 164 && id <= 170:
+		return 'I', id - 163, true
+	}
+	return 0, 0, false
+}
+
+// Calendar time (in the gps time scale) of a gps week and time of week
+func gpsTime(week uint16, tow float32) time.Time {
+	return gpsEpoch.Add(time.Duration(week) * 7 * 24 * time.Hour).Add(time.Duration(float64(tow) * float64(time.Second)))
+}
+
+// --- kml ---
+
+// Kml track of the navigation solution
+func writeKml(w io.Writer, data []telemetry.Telemetry) error {
+	fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(w, `<kml xmlns="http://www.opengis.net/kml/2.2">`)
+	fmt.Fprintln(w, `<Document>`)
+	fmt.Fprintln(w, `  <name>sturdr</name>`)
+	fmt.Fprintln(w, `  <Style id="track"><LineStyle><color>ff0000ff</color><width>3</width></LineStyle></Style>`)
+	fmt.Fprintln(w, `  <Placemark>`)
+	first, last := &data[0].Navigation, &data[len(data)-1].Navigation
+	fmt.Fprintf(w, "    <name>%d %s - %d %s</name>\n", first.Week, strconv.FormatFloat(float64(first.ToW), 'f', 3, 32),
+		last.Week, strconv.FormatFloat(float64(last.ToW), 'f', 3, 32))
+	fmt.Fprintln(w, `    <styleUrl>#track</styleUrl>`)
+	fmt.Fprintln(w, `    <LineString>`)
+	fmt.Fprintln(w, `      <altitudeMode>absolute</altitudeMode>`)
+	fmt.Fprintln(w, `      <coordinates>`)
+	for i := range data {
+		n := &data[i].Navigation
+		fmt.Fprintf(w, "        %.9f,%.9f,%.3f\n", n.Longitude, n.Latitude, n.Altitude)
+	}
+	fmt.Fprintln(w, `      </coordinates>`)
+	fmt.Fprintln(w, `    </LineString>`)
+	fmt.Fprintln(w, `  </Placemark>`)
+	fmt.Fprintln(w, `</Document>`)
+	_, err := fmt.Fprintln(w, `</kml>`)
+	return err
+}
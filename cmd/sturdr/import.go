@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sturdivant20/sturdr-api/include/client"
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// Post telemetry epochs from a file in the client format (EX: a json array as written by "get", or a binary telemetry file)
+func runImport(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	in := fs.String("i", "", "input file (default stdin)")
+	batch := fs.Int("batch", 500, "epochs stored per transaction")
+	fs.Parse(args)
+
+	r := io.Reader(os.Stdin)
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	codec, ok := encoder.Lookup(string(c.Format))
+	if !ok {
+		return fmt.Errorf("unknown format '%s'", c.Format)
+	}
+	var data []telemetry.Telemetry
+	if err := codec.Decode(bufio.NewReader(r), &data); err != nil {
+		return err
+	}
+
+	if *batch < 1 {
+		*batch = 1
+	}
+	for i := 0; i < len(data); i += *batch {
+		j := min(i+*batch, len(data))
+		if err := c.CreateTelemetryBatch(ctx, data[i:j]); err != nil {
+			return fmt.Errorf("epochs %d-%d (sequence %d-%d): %w", i, j-1,
+				data[i].Navigation.Sequence, data[j-1].Navigation.Sequence, err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "imported %d epochs\n", len(data))
+	return nil
+}
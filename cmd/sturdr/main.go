@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sturdivant20/sturdr-api/include/client"
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+)
+
+const usage = `Usage: sturdr [-server URL] [-key KEY] [-ca FILE] [-cert FILE -cert-key FILE] [-format json|binary|msgpack|cbor] <command> [options]
+
+Commands:
+  tail     follow live telemetry
+  get      read navigation, satellite or telemetry data
+  export   export telemetry to a csv, rinex or kml file
+  import   post telemetry from a file to the server
+  stats    summarize telemetry over a time range
+  truth    post a reference trajectory (csv or sbet) to the server
+  compare  summarize navigation errors against the reference trajectory
+
+Run 'sturdr <command> -h' for the options of each command.
+`
+
+func main() {
+	server := flag.String("server", envOr("STURDR_SERVER", "http://localhost:8000"), "sturdr server address")
+	key := flag.String("key", os.Getenv("STURDR_API_KEY"), "api key sent with every request")
+	ca := flag.String("ca", "", "ca bundle that verifies an https server (default system roots)")
+	cert := flag.String("cert", "", "client certificate for mutual tls")
+	cert_key := flag.String("cert-key", "", "client certificate private key")
+	format := flag.String("format", "json", "wire format (json, binary, msgpack or cbor)")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage); flag.PrintDefaults() }
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	c := client.NewClient(*server)
+	c.Format = client.Format(*format)
+	c.ApiKey = *key
+	if *ca != "" || *cert != "" {
+		cfg, err := tlsConfig(*ca, *cert, *cert_key)
+		if err != nil {
+			fatalf("%s", err.Error())
+		}
+		c.HttpClient.Transport = &http.Transport{TLSClientConfig: cfg, Proxy: http.ProxyFromEnvironment}
+	}
+	if _, ok := encoder.Lookup(*format); !ok || c.Format == "csv" {
+		fatalf("unknown format '%s'", *format)
+	}
+
+	// Create a context that listens for SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	args := flag.Args()[1:]
+	switch flag.Arg(0) {
+	case "tail":
+		err = runTail(ctx, c, args)
+	case "get":
+		err = runGet(ctx, c, args)
+	case "export":
+		err = runExport(ctx, c, args)
+	case "import":
+		err = runImport(ctx, c, args)
+	case "stats":
+		err = runStats(ctx, c, args)
+	case "truth":
+		err = runTruth(ctx, c, args)
+	case "compare":
+		err = runCompare(ctx, c, args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fatalf("%s", err.Error())
+	}
+}
+
+// Time range options shared by the read commands
+type rangeFlags struct {
+	week *uint
+	tow  *float64
+	all  *bool
+}
+
+func addRangeFlags(fs *flag.FlagSet) rangeFlags {
+	return rangeFlags{
+		week: fs.Uint("week", 0, "gps week of the first epoch"),
+		tow:  fs.Float64("tow", -1, "gps time of week of the first epoch (default latest epoch only)"),
+		all:  fs.Bool("all", false, "read every stored epoch"),
+	}
+}
+
+// Build the read query, only the latest epoch is read unless a start time is given
+func (rf rangeFlags) query() client.Query {
+	if *rf.all {
+		return client.Since(0, 0)
+	}
+	if *rf.tow < 0 {
+		return client.Latest()
+	}
+	return client.Since(uint16(*rf.week), float32(*rf.tow))
+}
+
+// Tls settings from a ca bundle and/or a client certificate
+func tlsConfig(ca string, cert string, key string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if ca != "" {
+		pem, err := os.ReadFile(ca)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in '%s'", ca)
+		}
+	}
+	if cert != "" {
+		pair, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{pair}
+	}
+	return cfg, nil
+}
+
+func envOr(key string, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "sturdr: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sturdivant20/sturdr-api/include/client"
+)
+
+// Follow the live telemetry stream
+func runTail(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	raw := fs.Bool("json", false, "print every epoch as a json line instead of a summary")
+	fs.Parse(args)
+
+	sub, err := c.Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for data := range sub.C {
+		if *raw {
+			if err := enc.Encode(data); err != nil {
+				return err
+			}
+			continue
+		}
+		n := &data.Navigation
+		fmt.Printf("%4d %10.3f  lat %12.8f  lon %13.8f  alt %8.2f  sats %2d  pdop %5.2f\n",
+			n.Week, n.ToW, n.Latitude, n.Longitude, n.Altitude, len(data.Satellites), n.PDOP)
+	}
+	return sub.Err()
+}
+
+// Read stored data and print it as json
+func runGet(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("get", flag.ExitOnError)
+	resource := fs.String("resource", "telemetry", "navigation, satellite or telemetry")
+	prn := fs.Int("prn", -1, "satellite prn (satellite resource only)")
+	rf := addRangeFlags(fs)
+	fs.Parse(args)
+
+	q := rf.query()
+	var data any
+	var err error
+	switch *resource {
+	case "navigation":
+		data, err = c.ReadNavigation(ctx, q)
+	case "satellite":
+		if *prn >= 0 {
+			q = q.PRN(uint8(*prn))
+		}
+		data, err = c.ReadSatellite(ctx, q)
+	case "telemetry":
+		data, err = c.ReadTelemetry(ctx, q)
+	default:
+		return fmt.Errorf("unknown resource '%s'", *resource)
+	}
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/sturdivant20/sturdr-api/include/analysis"
+	"github.com/sturdivant20/sturdr-api/include/client"
+)
+
+func formatSummary(s analysis.Summary) string {
+	if s.Count == 0 {
+		return "--"
+	}
+	return fmt.Sprintf("mean %9.3f  std %8.3f  min %9.3f  p50 %9.3f  max %9.3f", s.Mean, s.Std, s.Min, s.P50, s.Max)
+}
+
+// Summarize telemetry over a time range (computed by the server)
+func runStats(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	week := fs.Uint("week", 0, "gps week of the first epoch")
+	tow := fs.Float64("tow", -1, "gps time of week of the first epoch (default every stored epoch)")
+	end_week := fs.Uint("end-week", 0, "gps week of the last epoch")
+	end_tow := fs.Float64("end-tow", -1, "gps time of week of the last epoch (default latest epoch)")
+	fs.Parse(args)
+
+	q := client.Latest()
+	if *tow >= 0 {
+		q = client.Since(uint16(*week), float32(*tow))
+	}
+	if *end_tow >= 0 {
+		q = q.Until(uint16(*end_week), float32(*end_tow))
+	}
+
+	st, err := c.ReadStats(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	n := &st.Navigation
+	fmt.Printf("epochs  %d  (%d %.3f - %d %.3f, %.1f s)\n",
+		n.Epochs, st.StartWeek, st.StartToW, st.EndWeek, st.EndToW, n.Duration)
+	fmt.Printf("alt     %s\n", formatSummary(n.Altitude))
+	fmt.Printf("spread  north %.3f  east %.3f  up %.3f  drms %.3f [m]\n",
+		n.Spread.North, n.Spread.East, n.Spread.Up, n.Spread.Horizontal)
+	fmt.Printf("speed   %s\n", formatSummary(n.Speed))
+	fmt.Printf("v_up    %s\n", formatSummary(n.VerticalSpeed))
+	fmt.Printf("n_sat   %s\n", formatSummary(n.NSat))
+	fmt.Printf("pdop    %s\n", formatSummary(n.PDOP))
+	fmt.Printf("hdop    %s\n", formatSummary(n.HDOP))
+	fmt.Printf("vdop    %s\n", formatSummary(n.VDOP))
+
+	fmt.Println("\nprn     epochs  arcs  tracked [s]  health  c/no [dB-Hz]")
+	for _, sv := range st.Satellites {
+		label := fmt.Sprint(sv.PRN)
+		if sys, num, ok := rinexSatellite(sv.PRN); ok {
+			label = fmt.Sprintf("%c%02d", sys, num)
+		}
+		fmt.Printf("%-6s  %6d  %4d  %11.1f  %6d  %s\n",
+			label, sv.Epochs, sv.Arcs, sv.Tracked, sv.Health, formatSummary(sv.CNo))
+	}
+	return nil
+}
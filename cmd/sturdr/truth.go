@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sturdivant20/sturdr-api/include/client"
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/truth"
+)
+
+// Post a reference trajectory from a csv, sbet or client format file
+func runTruth(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("truth", flag.ExitOnError)
+	in := fs.String("i", "", "input file (default stdin)")
+	kind := fs.String("type", "", "csv, sbet or the client format (default from the file extension)")
+	week := fs.Int("week", -1, "gps week of the sbet times of week")
+	batch := fs.Int("batch", 5000, "epochs stored per request")
+	fs.Parse(args)
+
+	r := io.Reader(os.Stdin)
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	if *kind == "" {
+		switch strings.ToLower(filepath.Ext(*in)) {
+		case ".csv":
+			*kind = "csv"
+		case ".sbet", ".out":
+			*kind = "sbet"
+		default:
+			*kind = string(c.Format)
+		}
+	}
+
+	var data []truth.Truth
+	switch *kind {
+	case "sbet":
+		if *week < 0 || *week > 0xFFFF {
+			return errors.New("sbet files need the gps week as -week")
+		}
+		var err error
+		if data, err = truth.ReadSbet(bufio.NewReader(r), uint16(*week)); err != nil {
+			return err
+		}
+	default:
+		codec, ok := encoder.Lookup(*kind)
+		if !ok {
+			return fmt.Errorf("unknown type '%s'", *kind)
+		}
+		if err := codec.Decode(bufio.NewReader(r), &data); err != nil {
+			return err
+		}
+	}
+
+	if *batch < 1 {
+		*batch = 1
+	}
+	for i := 0; i < len(data); i += *batch {
+		j := min(i+*batch, len(data))
+		if err := c.ImportTruth(ctx, data[i:j]); err != nil {
+			return fmt.Errorf("epochs %d-%d (%d %.3f - %d %.3f): %w", i, j-1,
+				data[i].Week, data[i].ToW, data[j-1].Week, data[j-1].ToW, err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "imported %d truth epochs\n", len(data))
+	return nil
+}
+
+// Summarize navigation errors against the reference trajectory
+func runCompare(ctx context.Context, c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	week := fs.Uint("week", 0, "gps week of the first epoch")
+	tow := fs.Float64("tow", -1, "gps time of week of the first epoch (default every stored epoch)")
+	end_week := fs.Uint("end-week", 0, "gps week of the last epoch")
+	end_tow := fs.Float64("end-tow", -1, "gps time of week of the last epoch (default latest epoch)")
+	gap := fs.Float64("gap", 0, "longest truth gap interpolated across [s] (default server setting)")
+	fs.Parse(args)
+
+	q := client.Latest().Gap(*gap)
+	if *tow >= 0 {
+		q = client.Since(uint16(*week), float32(*tow)).Gap(*gap)
+	}
+	if *end_tow >= 0 {
+		q = q.Until(uint16(*end_week), float32(*end_tow))
+	}
+
+	cmp, err := c.CompareTruth(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	p := &cmp.Position
+	fmt.Printf("epochs  %d  (%d %.3f - %d %.3f, %d without truth)\n",
+		cmp.Epochs, cmp.StartWeek, cmp.StartToW, cmp.EndWeek, cmp.EndToW, cmp.Unmatched)
+	fmt.Printf("bias    east %.3f  north %.3f  up %.3f [m]\n", p.Bias.East, p.Bias.North, p.Bias.Up)
+	fmt.Printf("rms     east %.3f  north %.3f  up %.3f  3d %.3f [m]\n", p.RMS.East, p.RMS.North, p.RMS.Up, p.RMS3D)
+	fmt.Printf("horiz   cep50 %.3f  cep95 %.3f  drms %.3f  2drms %.3f [m]\n", p.CEP50, p.CEP95, p.DRMS, p.TwoDRMS)
+	fmt.Printf("vel_e   %s\n", formatSummary(cmp.Velocity.East))
+	fmt.Printf("vel_n   %s\n", formatSummary(cmp.Velocity.North))
+	fmt.Printf("vel_u   %s\n", formatSummary(cmp.Velocity.Up))
+	fmt.Printf("roll    %s\n", formatSummary(cmp.Attitude.Roll))
+	fmt.Printf("pitch   %s\n", formatSummary(cmp.Attitude.Pitch))
+	fmt.Printf("yaw     %s\n", formatSummary(cmp.Attitude.Yaw))
+	return nil
+}
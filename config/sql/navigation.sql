@@ -18,7 +18,7 @@ CREATE TABLE IF NOT EXISTS navigation (
   vdop REAL NOT NULL
 );
 
-CREATE INDEX idx_gps_time 
+CREATE INDEX IF NOT EXISTS idx_gps_time 
 ON navigation (week DESC, tow DESC);
 
 -- name: create_navigation
@@ -40,7 +40,7 @@ ORDER BY week ASC, tow ASC;
 -- name: update_navigation
 UPDATE navigation
 SET sequence = $1, week = $2, tow = $3, n_sat = $4, latitude = $5, longitude = $6, altitude = $7, vn = $8, ve = $9, vd = $10, roll = $11, pitch = $12, yaw = $13, pdop = $14, hdop = $15, vdop = $16
-WHERE sequence = $1;
+WHERE sequence = $17;
 
 -- name: delete_navigation
 DELETE FROM navigation
@@ -0,0 +1,33 @@
+-- name: make_slip_table
+CREATE TABLE IF NOT EXISTS slips (
+  week INTEGER NOT NULL CHECK (week >= 0),
+  tow REAL NOT NULL CHECK (tow BETWEEN 0 AND 604800),
+  prn INTEGER NOT NULL CHECK (prn >= 0),
+  kind TEXT NOT NULL,
+  cycles REAL NOT NULL,
+  lock REAL NOT NULL,
+  cno REAL NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_slip_event
+ON slips (prn ASC, week ASC, tow ASC, kind ASC);
+
+-- name: create_slip
+INSERT OR REPLACE INTO slips (week, tow, prn, kind, cycles, lock, cno)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: read_slip_span
+SELECT week, tow, prn, kind, cycles, lock, cno
+FROM slips
+WHERE ((week > $1) OR (week = $1 AND tow >= $2)) AND ((week < $3) OR (week = $3 AND tow <= $4)) AND ($5 < 0 OR prn = $5)
+ORDER BY prn ASC, week ASC, tow ASC;
+
+-- name: delete_slip_span
+DELETE FROM slips
+WHERE ((week > $1) OR (week = $1 AND tow >= $2)) AND ((week < $3) OR (week = $3 AND tow <= $4)) AND ($5 < 0 OR prn = $5);
+
+-- name: read_slip_satellite_span
+SELECT week, tow, prn, doppler, adr, cno, ip, qp
+FROM satellites
+WHERE ((week > $1) OR (week = $1 AND tow >= $2)) AND ((week < $3) OR (week = $3 AND tow <= $4)) AND ($5 < 0 OR prn = $5)
+ORDER BY week ASC, tow ASC, prn ASC;
@@ -0,0 +1,17 @@
+-- name: read_navigation_span
+SELECT week, tow, n_sat, latitude, longitude, altitude, vn, ve, vd, pdop, hdop, vdop
+FROM navigation
+WHERE ((week > $1) OR (week = $1 AND tow >= $2)) AND ((week < $3) OR (week = $3 AND tow <= $4))
+ORDER BY week ASC, tow ASC;
+
+-- name: read_satellite_span
+SELECT prn, week, tow, health, elevation, cno
+FROM satellites
+WHERE ((week > $1) OR (week = $1 AND tow >= $2)) AND ((week < $3) OR (week = $3 AND tow <= $4))
+ORDER BY prn ASC, week ASC, tow ASC;
+
+-- name: read_position_span
+SELECT week, tow, latitude, longitude, altitude
+FROM navigation
+WHERE ((week > $1) OR (week = $1 AND tow >= $2)) AND ((week < $3) OR (week = $3 AND tow <= $4))
+ORDER BY week ASC, tow ASC;
@@ -29,15 +29,15 @@ ORDER BY week ASC, tow ASC;
 -- name: update_telemetry
 UPDATE navigation
 SET sequence = $1, week = $2, tow = $3, n_sat = $4, latitude = $5, longitude = $6, altitude = $7, vn = $8, ve = $9, vd = $10, roll = $11, pitch = $12, yaw = $13, pdop = $14, hdop = $15, vdop = $16
-WHERE sequence = $1;
+WHERE sequence = $17;
 
 UPDATE satellites
 SET sequence = $1, week = $2, tow = $3, prn = $4, health = $5, x = $6, y = $7, z = $8, vx = $9, vy = $10, vz = $11, doppler = $12, psr = $13, adr = $14, azimuth = $15, elevation = $16, cno = $17, ie = $18, ip = $19, il = $20, qe = $21, qp = $22, ql = $23
-WHERE row = $24;
+WHERE sequence = $24 AND prn = $4;
 
 -- name: delete_telemetry
 DELETE FROM navigation
 WHERE sequence = $1;
 
 DELETE FROM satellites
-WHERE row = $1;
\ No newline at end of file
+WHERE sequence = $1;
\ No newline at end of file
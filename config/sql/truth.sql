@@ -0,0 +1,37 @@
+-- name: make_truth_table
+CREATE TABLE IF NOT EXISTS truth (
+  week INTEGER NOT NULL CHECK (week >= 0),
+  tow REAL NOT NULL CHECK (tow BETWEEN 0 AND 604800),
+  latitude REAL NOT NULL,
+  longitude REAL NOT NULL,
+  altitude REAL NOT NULL,
+  vn REAL NOT NULL,
+  ve REAL NOT NULL,
+  vd REAL NOT NULL,
+  roll REAL NOT NULL,
+  pitch REAL NOT NULL,
+  yaw REAL NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_truth_time
+ON truth (week ASC, tow ASC);
+
+-- name: create_truth
+INSERT OR REPLACE INTO truth (week, tow, latitude, longitude, altitude, vn, ve, vd, roll, pitch, yaw)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: read_truth_span
+SELECT week, tow, latitude, longitude, altitude, vn, ve, vd, roll, pitch, yaw
+FROM truth
+WHERE ((week > $1) OR (week = $1 AND tow >= $2)) AND ((week < $3) OR (week = $3 AND tow <= $4))
+ORDER BY week ASC, tow ASC;
+
+-- name: delete_truth_span
+DELETE FROM truth
+WHERE ((week > $1) OR (week = $1 AND tow >= $2)) AND ((week < $3) OR (week = $3 AND tow <= $4));
+
+-- name: read_navigation_span
+SELECT week, tow, latitude, longitude, altitude, vn, ve, vd, roll, pitch, yaw
+FROM navigation
+WHERE ((week > $1) OR (week = $1 AND tow >= $2)) AND ((week < $3) OR (week = $3 AND tow <= $4))
+ORDER BY week ASC, tow ASC;
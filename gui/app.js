@@ -2,6 +2,7 @@
 // 1. CONFIGURATION & STATE
 // ==========================================
 const API_ENDPOINT = `/telemetry/read?format=json`;
+const STREAM_ENDPOINT = `/telemetry/stream`;
 const MONO_FONT = "Monaco, 'Courier New', monospace";
 
 let CURRENT_PRN = null;
@@ -12,18 +13,28 @@ let LAST_WEEK = null;
 // 2. UTILITY FUNCTIONS
 // ==========================================
 
-async function remoteLog(...args) {
+async function remoteLog(level, ...args) {
     const message = args.map(arg => typeof arg === "object" ? JSON.stringify(arg) : arg).join(" ");
     try {
-        await fetch("/guilog", { method: "POST", body: message });
+        await fetch(`/guilog?level=${level}`, { method: "POST", body: message });
     } catch (e) {
         originalLog("Remote logging failed:", e);
     }
 }
 const originalLog = console.log;
+const originalWarn = console.warn;
+const originalError = console.error;
 console.log = function(...args) {
     originalLog.apply(console, args);
-    remoteLog(...args);
+    remoteLog("info", ...args);
+};
+console.warn = function(...args) {
+    originalWarn.apply(console, args);
+    remoteLog("warn", ...args);
+};
+console.error = function(...args) {
+    originalError.apply(console, args);
+    remoteLog("error", ...args);
 };
 
 function getSatMetadata(id) {
@@ -136,26 +147,30 @@ async function fetchAndUpdateNavigationData() {
         const data = await response.json();
         
         // Handle if your Go server returns an array or a single object
-        const payload = Array.isArray(data) ? data[0] : data;
-
-        if (payload.navigation) {
-            LAST_TOW = payload.navigation.tow;
-            LAST_WEEK = payload.navigation.week;
-            updateNavigationPanel(payload.navigation);
-            if (map) updateNavigationMap(payload.navigation);
-        }
-        
-        if (payload.satellites) {
-            updateNavigationCharts(payload.satellites);
-            const selector = document.getElementById("sat-selector");
-            if (selector) refreshSatelliteList(payload.satellites);
-        }
+        updateTelemetry(Array.isArray(data) ? data[0] : data);
 
     } catch (error) {
         console.error("Fetch error:", error);
     }
 }
 
+function updateTelemetry(payload) {
+    if (!payload) return;
+
+    if (payload.navigation) {
+        LAST_TOW = payload.navigation.tow;
+        LAST_WEEK = payload.navigation.week;
+        updateNavigationPanel(payload.navigation);
+        if (map) updateNavigationMap(payload.navigation);
+    }
+    
+    if (payload.satellites) {
+        updateNavigationCharts(payload.satellites);
+        const selector = document.getElementById("sat-selector");
+        if (selector) refreshSatelliteList(payload.satellites);
+    }
+}
+
 function updateNavigationPanel(nav) {
     const timeEl = document.getElementById("val-time");
     if (!timeEl) return;
@@ -233,9 +248,9 @@ if (selector) {
 }
 
 async function fetchAndPlotSatelliteHistory(prn, week, tow) {
-    const url = `/satellite/read?format=json&prn=${prn}&week=${week}&tow=${tow}`;
+    const url = `/satellite/read?format=json&prn=${prn}&week=${week}&tow=${tow}&fields=tow,doppler,cno,psr,ie,ip,il,qe,qp,ql&points=2000`;
     try {
-        const res = await fetch(url);
+        const res = await fetch(url, { cache: "no-cache" }); // revalidated, 304 when nothing new arrived
         const history = await res.json();
         
         const times = history.map(h => h.tow);
@@ -253,5 +268,22 @@ async function fetchAndPlotSatelliteHistory(prn, week, tow) {
 // ==========================================
 // 6. STARTUP
 // ==========================================
-setInterval(fetchAndUpdateNavigationData, 500);
+let pollTimer = null;
+function startPolling() {
+    if (!pollTimer) pollTimer = setInterval(fetchAndUpdateNavigationData, 500);
+}
+function stopPolling() {
+    if (pollTimer) clearInterval(pollTimer);
+    pollTimer = null;
+}
+
+// live (and replayed) epochs are pushed over the stream, polling is only a fallback
+if (window.EventSource) {
+    const stream = new EventSource(STREAM_ENDPOINT);
+    stream.onopen = () => stopPolling();
+    stream.onmessage = (e) => updateTelemetry(JSON.parse(e.data));
+    stream.onerror = () => startPolling();
+} else {
+    startPolling();
+}
 fetchAndUpdateNavigationData(); // The function name now matches
\ No newline at end of file
@@ -0,0 +1,55 @@
+package analysis
+
+import "math"
+
+// East, north and up components [m]
+type ENU struct {
+	East  float64 `json:"east"`
+	North float64 `json:"north"`
+	Up    float64 `json:"up"`
+}
+
+// Standard accuracy figures of position errors in a local level frame [m]
+type Accuracy struct {
+	Bias       ENU     `json:"bias"` // mean error
+	RMS        ENU     `json:"rms"`
+	RMS3D      float64 `json:"rms_3d"`
+	DRMS       float64 `json:"drms"`  // horizontal rms
+	TwoDRMS    float64 `json:"2drms"` // twice the drms (~95-98% of the horizontal errors)
+	CEP50      float64 `json:"cep50"` // radius holding 50% of the horizontal errors
+	CEP95      float64 `json:"cep95"`
+	Horizontal Summary `json:"horizontal"` // horizontal error magnitude
+	Vertical   Summary `json:"vertical"`   // signed up error
+}
+
+// Accuracy of a set of east/north/up errors (CEP are exact percentiles of the horizontal errors)
+func PositionAccuracy(errs []ENU) Accuracy {
+	if len(errs) == 0 {
+		return Accuracy{}
+	}
+	var a Accuracy
+	horizontal := make([]float64, len(errs))
+	vertical := make([]float64, len(errs))
+	for i, e := range errs {
+		a.Bias.East += e.East
+		a.Bias.North += e.North
+		a.Bias.Up += e.Up
+		a.RMS.East += e.East * e.East
+		a.RMS.North += e.North * e.North
+		a.RMS.Up += e.Up * e.Up
+		horizontal[i] = math.Hypot(e.East, e.North)
+		vertical[i] = e.Up
+	}
+	n := float64(len(errs))
+	a.Bias = ENU{East: a.Bias.East / n, North: a.Bias.North / n, Up: a.Bias.Up / n}
+	a.DRMS = math.Sqrt((a.RMS.East + a.RMS.North) / n)
+	a.TwoDRMS = 2 * a.DRMS
+	a.RMS3D = math.Sqrt((a.RMS.East + a.RMS.North + a.RMS.Up) / n)
+	a.RMS = ENU{East: math.Sqrt(a.RMS.East / n), North: math.Sqrt(a.RMS.North / n), Up: math.Sqrt(a.RMS.Up / n)}
+
+	a.Horizontal = Summarize(horizontal)
+	a.Vertical = Summarize(vertical)
+	a.CEP50 = Percentile(horizontal, 0.50) // sorted by Summarize
+	a.CEP95 = Percentile(horizontal, 0.95)
+	return a
+}
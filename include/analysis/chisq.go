@@ -0,0 +1,87 @@
+package analysis
+
+import "math"
+
+// Probability that a chi-square variable with dof degrees of freedom is below x
+func ChiSquareCDF(x float64, dof int) float64 {
+	if x <= 0 || dof < 1 {
+		return 0
+	}
+	return gammaP(float64(dof)/2, x/2)
+}
+
+// Value a chi-square variable with dof degrees of freedom is below with probability p (EX: the detection threshold
+// of a false alarm probability pfa is ChiSquareQuantile(1-pfa, dof))
+func ChiSquareQuantile(p float64, dof int) float64 {
+	if !(p > 0) || dof < 1 {
+		return 0
+	}
+	if p >= 1 {
+		return math.Inf(1)
+	}
+
+	// bracket the quantile, then bisect (the cdf is monotonic)
+	lo, hi := 0.0, float64(dof)
+	for ChiSquareCDF(hi, dof) < p {
+		lo, hi = hi, 2*hi
+	}
+	for range 200 {
+		mid := (lo + hi) / 2
+		if ChiSquareCDF(mid, dof) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+		if hi-lo <= 1e-10*hi {
+			break
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// Value a standard normal variable is below with probability p
+func NormalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// Regularized lower incomplete gamma function, a series below a+1 and a continued fraction above (Numerical Recipes)
+func gammaP(a float64, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1.0; n < 1000; n++ {
+			term *= x / (a + n)
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return sum * math.Exp(-x+a*math.Log(x)-lg)
+	}
+
+	// modified Lentz evaluation of the upper function
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1.0; i < 1000; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return 1 - math.Exp(-x+a*math.Log(x)-lg)*h
+}
@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChiSquareQuantile(t *testing.T) {
+	// table values
+	tests := []struct {
+		p    float64
+		dof  int
+		want float64
+	}{
+		{0.95, 1, 3.841459},
+		{0.99, 1, 6.634897},
+		{0.95, 2, 5.991465},
+		{0.5, 2, 1.386294},
+		{0.95, 3, 7.814728},
+		{0.99, 4, 13.276704},
+		{0.05, 4, 0.710723},
+		{0.999, 10, 29.588298},
+		{1 - 1e-5, 4, 28.473255},
+		{0.95, 30, 43.772972},
+	}
+	for _, tt := range tests {
+		got := ChiSquareQuantile(tt.p, tt.dof)
+		if math.Abs(got-tt.want) > 1e-5*tt.want {
+			t.Errorf("ChiSquareQuantile(%g, %d) = %.6f, want %.6f", tt.p, tt.dof, got, tt.want)
+		}
+		if p := ChiSquareCDF(got, tt.dof); math.Abs(p-tt.p) > 1e-9 {
+			t.Errorf("ChiSquareCDF(%g, %d) = %g, want %g", got, tt.dof, p, tt.p)
+		}
+	}
+
+	// out of range
+	if q := ChiSquareQuantile(0, 3); q != 0 {
+		t.Errorf("quantile of 0: %g", q)
+	}
+	if q := ChiSquareQuantile(1, 3); !math.IsInf(q, 1) {
+		t.Errorf("quantile of 1: %g", q)
+	}
+	if q := ChiSquareQuantile(0.5, 0); q != 0 {
+		t.Errorf("quantile without a degree of freedom: %g", q)
+	}
+}
+
+func TestChiSquareCDF(t *testing.T) {
+	// dof 2 is the exponential distribution of mean 2, both sides of the series/continued fraction switch
+	for _, x := range []float64{0.1, 1, 1.9, 2.1, 5, 20, 60} {
+		if got, want := ChiSquareCDF(x, 2), 1-math.Exp(-x/2); math.Abs(got-want) > 1e-12 {
+			t.Errorf("ChiSquareCDF(%g, 2) = %g, want %g", x, got, want)
+		}
+	}
+	if p := ChiSquareCDF(-1, 2); p != 0 {
+		t.Errorf("cdf below 0: %g", p)
+	}
+}
+
+func TestNormalQuantile(t *testing.T) {
+	tests := []struct {
+		p, want float64
+	}{
+		{0.5, 0},
+		{0.975, 1.959964},
+		{0.999, 3.090232},
+		{0.001, -3.090232},
+	}
+	for _, tt := range tests {
+		if got := NormalQuantile(tt.p); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("NormalQuantile(%g) = %.6f, want %.6f", tt.p, got, tt.want)
+		}
+	}
+}
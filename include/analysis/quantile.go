@@ -0,0 +1,100 @@
+package analysis
+
+import (
+	"math"
+	"slices"
+)
+
+// P² estimate of a quantile in constant memory (Jain & Chlamtac, 1985): five markers track the minimum, p/2, p,
+// (1+p)/2 and the maximum, and are moved with a piecewise parabolic fit as values arrive
+type Quantile struct {
+	p     float64
+	count int
+	q     [5]float64 // marker heights
+	n     [5]float64 // marker positions
+	want  [5]float64 // desired positions
+	step  [5]float64 // desired position increments
+}
+
+func NewQuantile(p float64) *Quantile {
+	return &Quantile{
+		p:    p,
+		want: [5]float64{1, 1 + 2*p, 1 + 4*p, 3 + 2*p, 5},
+		step: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+}
+
+func (e *Quantile) Add(x float64) {
+	// 1. the first five values are kept sorted
+	if e.count < 5 {
+		e.q[e.count] = x
+		e.count++
+		if e.count == 5 {
+			slices.Sort(e.q[:])
+			e.n = [5]float64{1, 2, 3, 4, 5}
+		}
+		return
+	}
+	e.count++
+
+	// 2. cell of the value, extending the extremes
+	var k int
+	switch {
+	case x < e.q[0]:
+		e.q[0] = x
+		k = 0
+	case x >= e.q[4]:
+		e.q[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3 && x >= e.q[k+1]; k++ {
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		e.n[i]++
+	}
+	for i := range e.want {
+		e.want[i] += e.step[i]
+	}
+
+	// 3. adjust the middle markers that are off their desired position by a step or more
+	for i := 1; i < 4; i++ {
+		d := e.want[i] - e.n[i]
+		if (d >= 1 && e.n[i+1]-e.n[i] > 1) || (d <= -1 && e.n[i-1]-e.n[i] < -1) {
+			s := math.Copysign(1, d)
+			q := e.parabolic(i, s)
+			if !(e.q[i-1] < q && q < e.q[i+1]) {
+				q = e.linear(i, s)
+			}
+			e.q[i] = q
+			e.n[i] += s
+		}
+	}
+}
+
+func (e *Quantile) parabolic(i int, s float64) float64 {
+	return e.q[i] + s/(e.n[i+1]-e.n[i-1])*
+		((e.n[i]-e.n[i-1]+s)*(e.q[i+1]-e.q[i])/(e.n[i+1]-e.n[i])+
+			(e.n[i+1]-e.n[i]-s)*(e.q[i]-e.q[i-1])/(e.n[i]-e.n[i-1]))
+}
+
+func (e *Quantile) linear(i int, s float64) float64 {
+	j := i + int(s)
+	return e.q[i] + s*(e.q[j]-e.q[i])/(e.n[j]-e.n[i])
+}
+
+// Current estimate (interpolated from the sorted values while there are less than five, NaN without values)
+func (e *Quantile) Value() float64 {
+	if e.count == 0 {
+		return math.NaN()
+	}
+	if e.count < 5 {
+		v := slices.Clone(e.q[:e.count])
+		slices.Sort(v)
+		pos := e.p * float64(len(v)-1)
+		lo := int(math.Floor(pos))
+		hi := min(lo+1, len(v)-1)
+		return v[lo] + (pos-float64(lo))*(v[hi]-v[lo])
+	}
+	return e.q[2]
+}
@@ -0,0 +1,69 @@
+package analysis
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestQuantileFewValues(t *testing.T) {
+	// exact (interpolated) below five values
+	q := NewQuantile(0.5)
+	if !math.IsNaN(q.Value()) {
+		t.Errorf("empty estimate %g, want NaN", q.Value())
+	}
+	for _, x := range []float64{7, 1, 3} {
+		q.Add(x)
+	}
+	if q.Value() != 3 {
+		t.Errorf("median of 7, 1, 3: %g, want 3", q.Value())
+	}
+	q.Add(5)
+	if q.Value() != 4 {
+		t.Errorf("median of 7, 1, 3, 5: %g, want 4", q.Value())
+	}
+}
+
+func TestQuantileEstimate(t *testing.T) {
+	tests := []struct {
+		name string
+		draw func(r *rand.Rand) float64
+	}{
+		{"uniform", func(r *rand.Rand) float64 { return 10 * r.Float64() }},
+		{"normal", func(r *rand.Rand) float64 { return 3 + 2*r.NormFloat64() }},
+		{"exponential", func(r *rand.Rand) float64 { return r.ExpFloat64() }},
+	}
+	for _, tt := range tests {
+		r := rand.New(rand.NewSource(1))
+		x := make([]float64, 20000)
+		for i := range x {
+			x[i] = tt.draw(r)
+		}
+		sorted := slices.Clone(x)
+		slices.Sort(sorted)
+		spread := sorted[len(sorted)-1] - sorted[0]
+
+		for _, p := range []float64{0.05, 0.5, 0.95} {
+			q := NewQuantile(p)
+			for _, v := range x {
+				q.Add(v)
+			}
+			want := Percentile(sorted, p)
+			if math.Abs(q.Value()-want) > 0.01*spread {
+				t.Errorf("%s p=%g: estimate %g, exact %g", tt.name, p, q.Value(), want)
+			}
+		}
+	}
+}
+
+func TestQuantileSorted(t *testing.T) {
+	// values arriving in order only ever extend the maximum
+	q := NewQuantile(0.95)
+	for i := 0; i <= 1000; i++ {
+		q.Add(float64(i))
+	}
+	if math.Abs(q.Value()-950) > 5 {
+		t.Errorf("p95 of 0..1000: %g, want about 950", q.Value())
+	}
+}
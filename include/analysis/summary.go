@@ -0,0 +1,130 @@
+package analysis
+
+import (
+	"math"
+	"slices"
+)
+
+// Summary statistics of a value
+type Summary struct {
+	Count uint64  `json:"count"`
+	Mean  float64 `json:"mean"`
+	Std   float64 `json:"std"` // sample standard deviation
+	RMS   float64 `json:"rms"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	P05   float64 `json:"p05"` // percentiles (estimated, exact for up to 5 values)
+	P50   float64 `json:"p50"`
+	P95   float64 `json:"p95"`
+}
+
+// Streaming summary in constant memory (Welford mean/variance, P² percentiles)
+type Running struct {
+	n    uint64
+	mean float64
+	m2   float64
+	min  float64
+	max  float64
+	p05  *Quantile
+	p50  *Quantile
+	p95  *Quantile
+}
+
+func NewRunning() *Running {
+	return &Running{p05: NewQuantile(0.05), p50: NewQuantile(0.50), p95: NewQuantile(0.95)}
+}
+
+// Add a value (NaN is ignored)
+func (r *Running) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	if r.n == 0 || x < r.min {
+		r.min = x
+	}
+	if r.n == 0 || x > r.max {
+		r.max = x
+	}
+	r.n++
+	d := x - r.mean
+	r.mean += d / float64(r.n)
+	r.m2 += d * (x - r.mean)
+	r.p05.Add(x)
+	r.p50.Add(x)
+	r.p95.Add(x)
+}
+
+func (r *Running) Count() uint64 {
+	return r.n
+}
+
+func (r *Running) Mean() float64 {
+	return r.mean
+}
+
+// Sample standard deviation (0 for less than 2 values)
+func (r *Running) Std() float64 {
+	if r.n < 2 {
+		return 0
+	}
+	return math.Sqrt(r.m2 / float64(r.n-1))
+}
+
+func (r *Running) Summary() Summary {
+	if r.n == 0 {
+		return Summary{}
+	}
+	return Summary{
+		Count: r.n,
+		Mean:  r.mean,
+		Std:   r.Std(),
+		RMS:   math.Sqrt(r.mean*r.mean + r.m2/float64(r.n)),
+		Min:   r.min,
+		Max:   r.max,
+		P05:   r.p05.Value(),
+		P50:   r.p50.Value(),
+		P95:   r.p95.Value(),
+	}
+}
+
+// Exact summary of a set of values (sorts the slice, NaN values must be removed first)
+func Summarize(x []float64) Summary {
+	if len(x) == 0 {
+		return Summary{}
+	}
+	slices.Sort(x)
+	var mean, m2 float64
+	for i, v := range x {
+		d := v - mean
+		mean += d / float64(i+1)
+		m2 += d * (v - mean)
+	}
+	std := 0.0
+	if len(x) > 1 {
+		std = math.Sqrt(m2 / float64(len(x)-1))
+	}
+	return Summary{
+		Count: uint64(len(x)),
+		Mean:  mean,
+		Std:   std,
+		RMS:   math.Sqrt(mean*mean + m2/float64(len(x))),
+		Min:   x[0],
+		Max:   x[len(x)-1],
+		P05:   Percentile(x, 0.05),
+		P50:   Percentile(x, 0.50),
+		P95:   Percentile(x, 0.95),
+	}
+}
+
+// Linearly interpolated p-quantile (0 to 1) of sorted values
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	h := p * float64(len(sorted)-1)
+	i := int(math.Floor(h))
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (h-float64(i))*(sorted[i+1]-sorted[i])
+}
@@ -0,0 +1,99 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		x    []float64
+		want Summary
+	}{
+		{nil, Summary{}},
+		{[]float64{4}, Summary{Count: 1, Mean: 4, Std: 0, RMS: 4, Min: 4, Max: 4, P05: 4, P50: 4, P95: 4}},
+		// mean 5, sample variance 32/7, mean square 25 + 4
+		{[]float64{2, 4, 4, 4, 5, 5, 7, 9},
+			Summary{Count: 8, Mean: 5, Std: math.Sqrt(32.0 / 7.0), RMS: math.Sqrt(29), Min: 2, Max: 9,
+				P05: 2.7, P50: 4.5, P95: 8.3}},
+		{[]float64{-3, 3}, Summary{Count: 2, Mean: 0, Std: math.Sqrt(18), RMS: 3, Min: -3, Max: 3,
+			P05: -2.7, P50: 0, P95: 2.7}},
+	}
+	for _, tt := range tests {
+		got := Summarize(append([]float64(nil), tt.x...))
+		if !summaryNear(got, tt.want, 1e-9) {
+			t.Errorf("Summarize(%v) = %+v, want %+v", tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestRunning(t *testing.T) {
+	// same moments as the exact summary, offset far from zero (Welford keeps its precision there)
+	x := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	r := NewRunning()
+	for _, v := range x {
+		r.Add(1e9 + v)
+	}
+	r.Add(math.NaN())
+
+	s := r.Summary()
+	if s.Count != 8 || r.Count() != 8 {
+		t.Errorf("count %d, want 8 (NaN ignored)", s.Count)
+	}
+	if math.Abs(s.Mean-(1e9+5)) > 1e-6 {
+		t.Errorf("mean %f, want %f", s.Mean, 1e9+5)
+	}
+	if math.Abs(s.Std-math.Sqrt(32.0/7.0)) > 1e-6 {
+		t.Errorf("std %g, want %g", s.Std, math.Sqrt(32.0/7.0))
+	}
+	if s.Min != 1e9+2 || s.Max != 1e9+9 {
+		t.Errorf("min %f max %f, want %f and %f", s.Min, s.Max, 1e9+2, 1e9+9)
+	}
+
+	// rms about zero
+	r = NewRunning()
+	for _, v := range []float64{3, -3, 4, -4} {
+		r.Add(v)
+	}
+	if s := r.Summary(); math.Abs(s.RMS-math.Sqrt(12.5)) > 1e-12 || s.Mean != 0 {
+		t.Errorf("rms %g mean %g, want %g and 0", s.RMS, s.Mean, math.Sqrt(12.5))
+	}
+
+	// fewer than two values
+	r = NewRunning()
+	if s := r.Summary(); s != (Summary{}) {
+		t.Errorf("empty summary %+v", s)
+	}
+	r.Add(7)
+	if r.Std() != 0 {
+		t.Errorf("std of one value %g, want 0", r.Std())
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{10, 20, 30, 40, 50}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 10},
+		{0.25, 20},
+		{0.5, 30},
+		{0.6, 34},
+		{1, 50},
+	}
+	for _, tt := range tests {
+		if got := Percentile(sorted, tt.p); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Percentile(%g) = %g, want %g", tt.p, got, tt.want)
+		}
+	}
+	if !math.IsNaN(Percentile(nil, 0.5)) {
+		t.Error("percentile of no values is not NaN")
+	}
+}
+
+func summaryNear(a, b Summary, tol float64) bool {
+	near := func(x, y float64) bool { return math.Abs(x-y) <= tol }
+	return a.Count == b.Count && near(a.Mean, b.Mean) && near(a.Std, b.Std) && near(a.RMS, b.RMS) &&
+		near(a.Min, b.Min) && near(a.Max, b.Max) && near(a.P05, b.P05) && near(a.P50, b.P50) && near(a.P95, b.P95)
+}
@@ -3,25 +3,59 @@ package api
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sturdivant20/sturdr-api/include/generator"
+	"github.com/sturdivant20/sturdr-api/include/gnss"
+	"github.com/sturdivant20/sturdr-api/include/logging"
 	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/replay"
+	"github.com/sturdivant20/sturdr-api/include/rpc"
 	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/slips"
+	"github.com/sturdivant20/sturdr-api/include/stats"
 	"github.com/sturdivant20/sturdr-api/include/telemetry"
+	"github.com/sturdivant20/sturdr-api/include/truth"
+	"google.golang.org/grpc"
+)
+
+var (
+	logger    = logging.For("api")
+	guiLogger = logging.For("gui")
 )
 
 type Application struct {
-	cfg Config
-	db  *sql.DB
+	cfg       Config
+	db        *sql.DB
+	broker    *telemetry.Broker
+	generator *generator.Generator
+	replay    *replay.Controller
+	keys      []apiKey
+	certs     *certReloader // nil when serving plain http
+	grpc      *grpc.Server  // nil when grpc is disabled
+	data      *dataVersion  // validates conditional reads
+	started   time.Time
+	ingested  atomic.Int64 // unix time [ns] of the last receiver epoch (0 = none yet)
+	prepared  atomic.Bool  // every service has bound its sql statements (cleared when the database closes)
+	gauges    sync.Mutex   // receiver gauges are updated together
+	shutdown  atomic.Bool  // graceful shutdown has started
 }
 
 // Init
 func (app *Application) Init(cfg_fname string) error {
 	var err error
+	app.started = time.Now()
 
 	// 1. parse toml config file
 	app.cfg, err = parseSettings(cfg_fname)
@@ -29,7 +63,39 @@ func (app *Application) Init(cfg_fname string) error {
 		return err
 	}
 
-	// 2. initialize database file
+	// 2. configure logging
+	lc := &app.cfg.Logging
+	if err = logging.Setup(os.Stderr, logging.Options{Format: lc.Format, Level: lc.Level, Levels: lc.Levels}); err != nil {
+		logger.Error("Error configuring logging!", "error", err)
+		return err
+	}
+	logger.Info("Parsed TOML settings ...", "file", cfg_fname)
+	logger.Debug("Settings", "settings", app.cfg.String())
+
+	// 3. api keys
+	if app.keys, err = parseKeys(app.cfg.Auth.Keys); err != nil {
+		logger.Error("Error reading api keys!", "error", err)
+		return err
+	}
+	if app.cfg.Auth.Enabled {
+		logger.Info("Api key authentication enabled ...", "keys", len(app.keys), "public_read", app.cfg.Auth.PublicRead)
+	} else {
+		logger.Warn("Api key authentication disabled, anyone can create, update and delete data ...")
+	}
+
+	// 4. tls certificates
+	if err = checkTls(&app.cfg.Server); err != nil {
+		logger.Error("Error in tls settings!", "error", err)
+		return err
+	}
+	if app.cfg.Server.CertFile != "" {
+		if app.certs, err = newCertReloader(&app.cfg.Server); err != nil {
+			logger.Error("Error loading tls certificates!", "error", err)
+			return err
+		}
+	}
+
+	// 5. initialize database file
 	app.db, err = initDatabase(app.cfg.Database.DbFile, app.cfg.Database.Clear)
 	if err != nil {
 		return err
@@ -46,26 +112,122 @@ func (app *Application) Mount() http.Handler {
 	h_navigation := navigation.NewHttpHandler(s_navigation)
 	s_satellite := satellite.NewSatelliteService(app.db, sql.SatelliteCmds)
 	h_satellite := satellite.NewHttpHandler(s_satellite)
-	s_telemetry := telemetry.NewTelemetryService(app.db, sql.TelemetryCmds)
-	h_telemetry := telemetry.NewHttpHandler(s_telemetry)
+	app.broker = telemetry.NewBroker()
+	s_telemetry := telemetry.NewTelemetryService(app.db, sql.TelemetryCmds, app.broker)
+	if geo := &app.cfg.Geometry; geo.Check {
+		s_telemetry = telemetry.WithGeometryCheck(s_telemetry, gnss.CheckOptions{
+			Fill:          geo.Fill,
+			AzElTolerance: geo.AzElTolerance,
+			DopTolerance:  geo.DopTolerance,
+		})
+	}
+	if in := &app.cfg.Integrity; in.Monitor {
+		s_telemetry = telemetry.WithIntegrityMonitor(s_telemetry, gnss.IntegrityOptions{
+			Sigma: in.Sigma,
+			Pfa:   in.Pfa,
+			Pmd:   in.Pmd,
+			HAL:   in.Hal,
+			VAL:   in.Val,
+			Mask:  in.Mask,
+		})
+	}
+	s_slips := slips.NewSlipService(app.db, sql.SlipCmds)
+	slip_opts := slips.Options{
+		Threshold:     app.cfg.Slips.Threshold,
+		LockThreshold: app.cfg.Slips.LockThreshold,
+		MaxGap:        app.cfg.Slips.MaxGap,
+		AdrSign:       app.cfg.Slips.AdrSign,
+	}
+	if app.cfg.Slips.Detect {
+		s_telemetry = slips.WithSlipDetection(s_telemetry, s_slips, slip_opts)
+	}
+	h_slips := slips.NewHttpHandler(s_slips, slip_opts)
+	s_receiver := telemetry.WithReceiver(s_telemetry, app.observeReceiver) // the http and grpc ingest, and the generator standing in for a receiver
+	h_telemetry := telemetry.NewHttpHandler(s_receiver, app.broker)
+	app.data = newDataVersion(app.db, app.started)
+	h_stats := stats.NewHttpHandler(stats.NewStatsService(app.db, sql.StatsCmds))
+	h_truth := truth.NewHttpHandler(truth.NewTruthService(app.db, sql.TruthCmds))
+	c_replay := replay.NewController(app.broker, sql.TelemetryCmds, app.cfg.Replay.Dir, app.cfg.Replay.Speed,
+		app.cfg.Replay.Loop)
+	h_replay := replay.NewHttpHandler(c_replay)
+	h_navigation.WithReplay(c_replay.NavigationView())
+	h_satellite.WithReplay(c_replay.SatelliteView())
+	h_telemetry.WithReplay(c_replay.TelemetryView())
+	app.replay = c_replay
+	if app.cfg.Replay.File != "" {
+		if err := c_replay.LoadDatabase(context.Background(), app.cfg.Replay.File); err != nil {
+			logger.Error("Failed to load replay file!", "file", app.cfg.Replay.File, "error", err)
+		} else if app.cfg.Replay.Autoplay {
+			c_replay.Play()
+		}
+	}
+	if gen := &app.cfg.Generator; gen.Enabled {
+		app.generator = generator.NewGenerator(s_receiver, generator.Options{
+			Rate:          gen.Rate,
+			Latitude:      gen.Latitude,
+			Longitude:     gen.Longitude,
+			Altitude:      gen.Altitude,
+			Speed:         gen.Speed,
+			Heading:       gen.Heading,
+			TurnRate:      gen.TurnRate,
+			ElevationMask: gen.ElevationMask,
+			Gps:           gen.Gps,
+			Galileo:       gen.Galileo,
+			Sequence:      lastSequence(app.db), // continue after the stored epochs instead of colliding with them
+		})
+	}
+
+	if app.cfg.Server.GrpcPort > 0 {
+		app.grpc = app.newGrpcServer(rpc.NewServer(s_navigation, s_satellite, s_receiver, app.broker))
+	}
+
+	// every service above has bound its statements (a failed one exits the server)
+	app.prepared.Store(true)
 
 	// 2. create http endpoints
 	ep := &app.cfg.Endpoints
 	router := http.NewServeMux()
-	router.HandleFunc(ep.Navigation+ep.Create, h_navigation.Create)
-	router.HandleFunc(ep.Navigation+ep.Read, h_navigation.Read)
-	router.HandleFunc(ep.Navigation+ep.Update, h_navigation.Update)
-	router.HandleFunc(ep.Navigation+ep.Delete, h_navigation.Delete)
+	router.HandleFunc(ep.Navigation+ep.Create, app.require(roleIngest, app.requireClientCert(app.modifies(h_navigation.Create))))
+	router.HandleFunc(ep.Navigation+ep.Read, app.require(roleViewer, app.cached(h_navigation.Read)))
+	router.HandleFunc(ep.Navigation+ep.Update, app.require(roleAdmin, app.modifies(h_navigation.Update)))
+	router.HandleFunc(ep.Navigation+ep.Delete, app.require(roleAdmin, app.modifies(h_navigation.Delete)))
+
+	router.HandleFunc(ep.Satellite+ep.Create, app.require(roleIngest, app.requireClientCert(app.modifies(h_satellite.Create))))
+	router.HandleFunc(ep.Satellite+ep.Read, app.require(roleViewer, app.cached(h_satellite.Read)))
+	router.HandleFunc(ep.Satellite+"/multipath", app.require(roleViewer, app.cached(h_satellite.Multipath)))
+	router.HandleFunc(ep.Satellite+ep.Update, app.require(roleAdmin, app.modifies(h_satellite.Update)))
+	router.HandleFunc(ep.Satellite+ep.Delete, app.require(roleAdmin, app.modifies(h_satellite.Delete)))
+
+	router.HandleFunc(ep.Telemetry+ep.Create, app.require(roleIngest, app.requireClientCert(app.modifies(h_telemetry.Create))))
+	router.HandleFunc(ep.Telemetry+ep.Read, app.require(roleViewer, app.cached(h_telemetry.Read)))
+	router.HandleFunc(ep.Telemetry+ep.Update, app.require(roleAdmin, app.modifies(h_telemetry.Update)))
+	router.HandleFunc(ep.Telemetry+ep.Delete, app.require(roleAdmin, app.modifies(h_telemetry.Delete)))
+	router.HandleFunc(ep.Telemetry+"/geometry", app.require(roleViewer, app.cached(h_telemetry.Geometry)))
+	router.HandleFunc(ep.Telemetry+"/residuals", app.require(roleViewer, app.cached(h_telemetry.Residuals)))
+	router.HandleFunc(ep.Telemetry+"/integrity", app.require(roleViewer, app.cached(h_telemetry.Integrity)))
+	router.HandleFunc(ep.Telemetry+ep.Stream, app.require(roleViewer, h_telemetry.Stream))
+	router.HandleFunc(ep.Telemetry+ep.Batch, app.require(roleIngest, app.requireClientCert(app.modifies(h_telemetry.Batch))))
 
-	router.HandleFunc(ep.Satellite+ep.Create, h_satellite.Create)
-	router.HandleFunc(ep.Satellite+ep.Read, h_satellite.Read)
-	router.HandleFunc(ep.Satellite+ep.Update, h_satellite.Update)
-	router.HandleFunc(ep.Satellite+ep.Delete, h_satellite.Delete)
+	router.HandleFunc(ep.Stats, app.require(roleViewer, app.cached(h_stats.Read)))
+	router.HandleFunc(ep.Accuracy, app.require(roleViewer, app.cached(h_stats.Accuracy)))
 
-	router.HandleFunc(ep.Telemetry+ep.Create, h_telemetry.Create)
-	router.HandleFunc(ep.Telemetry+ep.Read, h_telemetry.Read)
-	router.HandleFunc(ep.Telemetry+ep.Update, h_telemetry.Update)
-	router.HandleFunc(ep.Telemetry+ep.Delete, h_telemetry.Delete)
+	router.HandleFunc(ep.Truth+"/import", app.require(roleIngest, app.requireClientCert(app.modifies(h_truth.Import))))
+	router.HandleFunc(ep.Truth+ep.Read, app.require(roleViewer, app.cached(h_truth.Read)))
+	router.HandleFunc(ep.Truth+"/compare", app.require(roleViewer, app.cached(h_truth.Compare)))
+	router.HandleFunc(ep.Truth+"/clear", app.require(roleAdmin, app.modifies(h_truth.Clear)))
+
+	router.HandleFunc(ep.Slips+ep.Read, app.require(roleViewer, app.cached(h_slips.Read)))
+	router.HandleFunc(ep.Slips+"/scan", app.require(roleAdmin, app.modifies(h_slips.Scan)))
+	router.HandleFunc(ep.Slips+"/clear", app.require(roleAdmin, app.modifies(h_slips.Clear)))
+
+	router.HandleFunc(ep.Replay+"/load", app.require(roleAdmin, h_replay.Load))
+	router.HandleFunc(ep.Replay+"/play", app.require(roleAdmin, h_replay.Play))
+	router.HandleFunc(ep.Replay+"/pause", app.require(roleAdmin, h_replay.Pause))
+	router.HandleFunc(ep.Replay+"/stop", app.require(roleAdmin, h_replay.Stop))
+	router.HandleFunc(ep.Replay+"/seek", app.require(roleAdmin, h_replay.Seek))
+	router.HandleFunc(ep.Replay+"/speed", app.require(roleAdmin, h_replay.Speed))
+	router.HandleFunc(ep.Replay+"/loop", app.require(roleAdmin, h_replay.Loop))
+	router.HandleFunc(ep.Replay+"/status", app.require(roleViewer, h_replay.Status))
 
 	// gui
 	fs := http.FileServer(http.Dir("./gui"))
@@ -80,9 +242,18 @@ func (app *Application) Mount() http.Handler {
 	router.HandleFunc("/satellite-view", func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, "./gui/sat-view.html")
 	})
-	router.HandleFunc("/guilog", remoteLogHandler)
+	router.HandleFunc("/guilog", app.require(roleViewer, remoteLogHandler))
 
-	return router
+	// health
+	router.HandleFunc("/healthz", app.healthz)
+	router.HandleFunc("/readyz", app.readyz)
+	router.HandleFunc("/version", app.version)
+	router.Handle("/metrics", promhttp.Handler())
+
+	if app.cfg.Server.Compression {
+		return instrument(compress(router))
+	}
+	return instrument(router)
 }
 
 // Run
@@ -95,42 +266,106 @@ func (app *Application) Run(ctx context.Context, router http.Handler) error {
 		ReadTimeout:  10 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	if app.broker != nil {
+		svr.RegisterOnShutdown(app.broker.Close)
+	}
+
+	if app.certs != nil {
+		svr.TLSConfig = app.certs.tlsConfig()
+	}
 
 	// run server in goroutine
-	serverErrors := make(chan error, 1)
+	serverErrors := make(chan error, 2)
 	go func() {
-		log.Printf("Server has started at address '%s' ...", addr)
+		if app.certs != nil {
+			logger.Info("Server has started ...", "address", addr, "tls", true,
+				"client_certs", app.cfg.Server.ClientCaFile != "")
+			serverErrors <- svr.ListenAndServeTLS("", "")
+			return
+		}
+		logger.Info("Server has started ...", "address", addr, "tls", false)
 		serverErrors <- svr.ListenAndServe()
 	}()
 
+	// run grpc server in goroutine
+	if app.grpc != nil {
+		grpc_addr := app.cfg.Server.Host + ":" + strconv.Itoa(app.cfg.Server.GrpcPort)
+		lis, err := net.Listen("tcp", grpc_addr)
+		if err != nil {
+			return err
+		}
+		go func() {
+			logger.Info("gRPC server has started ...", "address", grpc_addr, "tls", app.certs != nil)
+			serverErrors <- app.grpc.Serve(lis)
+		}()
+	}
+
+	// run background workers (simulated receiver, certificate reload) in goroutines
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+	if app.certs != nil {
+		interval := app.cfg.Server.ReloadInterval
+		if interval <= 0 {
+			interval = 10.0
+		}
+		go app.certs.watch(workerCtx, time.Duration(interval*float64(time.Second)))
+	}
+	if app.generator != nil {
+		go app.generator.Run(workerCtx)
+	}
+
 	// wait for termination signal or error
 	select {
 	case err := <-serverErrors:
 		return err
 	case <-ctx.Done():
-		log.Println("Shutdown signal received ...")
+		logger.Info("Shutdown signal received ...")
+
+		// 0. Report not ready, giving load balancers time to stop routing requests here
+		app.shutdown.Store(true)
+		if delay := app.cfg.Health.ShutdownDelay; delay > 0 {
+			logger.Info("Draining ...", "delay_s", delay)
+			time.Sleep(time.Duration(delay * float64(time.Second)))
+		}
 
 		// 1. Set a deadline for the HTTP server to finish active requests
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
 		// 2. Stop the HTTP server first
-		log.Println("Closing http server ...")
+		logger.Info("Closing http server ...")
 		if err := svr.Shutdown(shutdownCtx); err != nil {
 			// If shutdown fails (timeout), force close the server
 			svr.Close()
-			log.Printf("HTTP shutdown error: %s", err.Error())
+			logger.Error("HTTP shutdown error!", "error", err)
+		}
+
+		// 3. Stop the gRPC server (open subscriptions end when the broker closes)
+		if app.grpc != nil {
+			logger.Info("Closing grpc server ...")
+			stopped := make(chan struct{})
+			go func() {
+				app.grpc.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-shutdownCtx.Done():
+				app.grpc.Stop()
+				logger.Error("gRPC shutdown error!", "error", shutdownCtx.Err())
+			}
 		}
 
-		// 3. Now that no more requests are being processed, close the DB
+		// 4. Now that no more requests are being processed, close the DB
 		if app.db != nil {
-			log.Println("Closing database connection ...")
+			logger.Info("Closing database connection ...")
+			app.prepared.Store(false)
 			if err := app.db.Close(); err != nil {
-				log.Printf("Database close error: %s", err.Error())
+				logger.Error("Database close error!", "error", err)
 			}
 		}
 
-		log.Println("Graceful shutdown complete ...")
+		logger.Info("Graceful shutdown complete ...")
 		return nil
 	}
 }
@@ -146,21 +381,33 @@ func (app *Application) Run(ctx context.Context, router http.Handler) error {
 // 	})
 // }
 
+// Longest gui console message relayed into the server log [bytes]
+const maxGuiLog = 4 << 10
+
+// Relay gui console messages into the server log (source "gui", level from "?level=", default info)
 func remoteLogHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		return
 	}
 
 	// Read the log message from the request body
-	body, err := io.ReadAll(r.Body)
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxGuiLog))
 	if err != nil {
-		log.Printf("Error reading remote log: %v", err)
+		logger.WarnContext(r.Context(), "Error reading remote log!", "error", err)
+		var too_large *http.MaxBytesError
+		if errors.As(err, &too_large) {
+			http.Error(w, fmt.Sprintf("log message is longer than %d bytes", maxGuiLog), http.StatusRequestEntityTooLarge)
+		}
 		return
 	}
 	defer r.Body.Close()
 
 	// Print the message to your Go terminal
-	log.Printf("[GUI] %s", string(body))
+	level, err := logging.ParseLevel(r.URL.Query().Get("level"))
+	if err != nil {
+		level = slog.LevelInfo
+	}
+	guiLogger.Log(r.Context(), level, string(body))
 
 	// Respond with 200 OK
 	w.WriteHeader(http.StatusOK)
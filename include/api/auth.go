@@ -0,0 +1,137 @@
+package api
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Access level of an api key, every role can do everything the roles below it can
+type role int
+
+const (
+	rolePublic role = iota // no key
+	roleViewer             // read data
+	roleIngest             // create data
+	roleAdmin              // update/delete data and control the replay
+)
+
+func (r role) String() string {
+	switch r {
+	case roleViewer:
+		return "viewer"
+	case roleIngest:
+		return "ingest"
+	case roleAdmin:
+		return "admin"
+	}
+	return "public"
+}
+
+// Parse "viewer", "ingest" or "admin"
+func parseRole(s string) (role, error) {
+	switch strings.ToLower(s) {
+	case "viewer":
+		return roleViewer, nil
+	case "ingest":
+		return roleIngest, nil
+	case "admin":
+		return roleAdmin, nil
+	}
+	return rolePublic, fmt.Errorf("unknown role '%s'", s)
+}
+
+// Validated api key
+type apiKey struct {
+	name string
+	key  []byte
+	role role
+}
+
+// Check the configured api keys
+func parseKeys(cfg []ApiKeyConfig) ([]apiKey, error) {
+	keys := make([]apiKey, 0, len(cfg))
+	names := make(map[string]bool)
+	for i, k := range cfg {
+		if k.Name == "" {
+			k.Name = fmt.Sprintf("key-%d", i)
+		}
+		if len(k.Key) < 16 {
+			return nil, fmt.Errorf("api key '%s' is shorter than 16 characters", k.Name)
+		}
+		if names[k.Name] {
+			return nil, fmt.Errorf("api key name '%s' is used twice", k.Name)
+		}
+		names[k.Name] = true
+		r, err := parseRole(k.Role)
+		if err != nil {
+			return nil, fmt.Errorf("api key '%s': %w", k.Name, err)
+		}
+		keys = append(keys, apiKey{name: k.Name, key: []byte(k.Key), role: r})
+	}
+	return keys, nil
+}
+
+// Key sent with a request ("Authorization: Bearer <key>" or "X-API-Key: <key>")
+func requestKey(r *http.Request) string {
+	if k := r.Header.Get("X-API-Key"); k != "" {
+		return k
+	}
+	if k, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(k)
+	}
+	return ""
+}
+
+// Look up a sent key (every key is compared, so the timing does not depend on which one matched)
+func (app *Application) authenticate(key string) (apiKey, bool) {
+	sent := []byte(key)
+	var found apiKey
+	ok := false
+	for _, k := range app.keys {
+		if subtle.ConstantTimeCompare(sent, k.key) == 1 {
+			found, ok = k, true
+		}
+	}
+	return found, ok
+}
+
+// Role an endpoint actually requires (reads stay public unless configured otherwise)
+func (app *Application) required(min role) role {
+	if !app.cfg.Auth.Enabled || (min == roleViewer && app.cfg.Auth.PublicRead) {
+		return rolePublic
+	}
+	return min
+}
+
+// Wrap a handler so it requires a key with at least the given role
+func (app *Application) require(min role, next http.HandlerFunc) http.HandlerFunc {
+	if min = app.required(min); min == rolePublic {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if requestKey(r) == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="sturdr"`)
+			http.Error(w, "missing api key", http.StatusUnauthorized)
+			return
+		}
+		k, ok := app.authenticate(requestKey(r))
+		if !ok {
+			logger.WarnContext(r.Context(), "Rejected unknown api key!", "remote", r.RemoteAddr, "path", r.URL.Path)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="sturdr", error="invalid_token"`)
+			http.Error(w, "invalid api key", http.StatusUnauthorized)
+			return
+		}
+		if k.role < min {
+			logger.WarnContext(r.Context(), "Rejected api key without permission!",
+				"key", k.name, "role", k.role.String(), "required", min.String(), "path", r.URL.Path)
+			http.Error(w, fmt.Sprintf("api key '%s' (%s) cannot access this endpoint, %s required", k.name, k.role, min),
+				http.StatusForbidden)
+			return
+		}
+		logger.DebugContext(r.Context(), "Accepted api key", "key", k.name, "role", k.role.String())
+		next(w, r)
+	}
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Responses with a known smaller length are sent as is
+const minCompressSize = 512
+
+// Stream compressor shared by gzip and zstd
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// Encoders are reused between responses
+var compressors = map[string]*sync.Pool{
+	"zstd": {New: func() any {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+		return enc
+	}},
+	"gzip": {New: func() any {
+		enc, _ := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+		return enc
+	}},
+}
+
+// Compress responses with zstd or gzip when the client accepts it (EX: "Accept-Encoding: gzip, zstd")
+func compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := acceptEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// Preferred encoding of an Accept-Encoding header (zstd before gzip at equal q-values, "" = identity)
+func acceptEncoding(header string) string {
+	best, best_q := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "x-gzip" {
+			name = "gzip"
+		}
+		if _, ok := compressors[name]; !ok {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if q > best_q || (q == best_q && name == "zstd") {
+			best, best_q = name, q
+		}
+	}
+	return best
+}
+
+// Response writer that compresses the body once the headers show it is worth it
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	enc      compressor // nil until (and unless) the body is compressed
+	decided  bool
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if !w.decided {
+		w.decided = true
+		if compressible(code, w.Header()) {
+			h := w.Header()
+			h.Del("Content-Length")
+			h.Set("Content-Encoding", w.encoding)
+			if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+				h.Set("ETag", "W/"+etag) // the bytes differ from the identity response
+			}
+			w.enc = compressors[w.encoding].Get().(compressor)
+			w.enc.Reset(w.ResponseWriter)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Push compressed data out with every flush (for streams)
+func (w *compressWriter) FlushError() error {
+	if w.enc != nil {
+		if err := w.enc.Flush(); err != nil {
+			return err
+		}
+	}
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Allow http.ResponseController to reach the underlying writer (for streams)
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) close() {
+	if w.enc == nil {
+		return
+	}
+	w.enc.Close()
+	w.enc.Reset(nil)
+	compressors[w.encoding].Put(w.enc)
+	w.enc = nil
+}
+
+// Whether a response should be compressed (not empty, small, partial, already encoded, event streams or media)
+func compressible(code int, h http.Header) bool {
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified ||
+		code == http.StatusPartialContent || h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	if n, err := strconv.Atoi(h.Get("Content-Length")); err == nil && n < minCompressSize {
+		return false
+	}
+	ct := h.Get("Content-Type")
+	for _, prefix := range []string{"text/event-stream", "image/", "audio/", "video/", "application/zip", "application/gzip"} {
+		if strings.HasPrefix(ct, prefix) && ct != "image/svg+xml" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+)
+
+// Version of the stored data, validates conditional GETs of the read endpoints
+type dataVersion struct {
+	mu       sync.Mutex
+	sequence uint64    // latest telemetry sequence
+	revision uint64    // writes since startup (updates and deletes leave the sequence alone)
+	modified time.Time // last write
+	boot     string    // server start, distinguishes the revisions of different runs
+}
+
+func newDataVersion(db *sql.DB, started time.Time) *dataVersion {
+	v := &dataVersion{modified: started.UTC().Truncate(time.Second), boot: strconv.FormatInt(started.Unix(), 36)}
+	if db != nil {
+		v.sequence = lastSequence(db)
+	}
+	return v
+}
+
+// Highest sequence in the navigation table (0 when it is empty)
+func lastSequence(db *sql.DB) uint64 {
+	var seq sql.NullInt64
+	if err := db.QueryRow("SELECT MAX(sequence) FROM navigation").Scan(&seq); err != nil {
+		logger.Warn("Error reading latest sequence!", "error", err)
+	}
+	return uint64(seq.Int64)
+}
+
+// Record a new epoch
+func (v *dataVersion) observe(sequence uint64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.sequence = sequence
+	v.revision++
+	v.modified = time.Now().UTC().Truncate(time.Second)
+}
+
+// Record a write that did not publish an epoch
+func (v *dataVersion) touch() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.revision++
+	v.modified = time.Now().UTC().Truncate(time.Second)
+}
+
+// Weak entity tag (EX: W/"1523-7-sxk2p1") and last modification time
+func (v *dataVersion) current() (string, time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return fmt.Sprintf(`W/"%d-%d-%s"`, v.sequence, v.revision, v.boot), v.modified
+}
+
+// Answer a read with "304 Not Modified" when the client already has the current version (ETag/If-None-Match only,
+// Last-Modified has whole seconds and several writes can land in one, so If-Modified-Since is not trusted)
+func (app *Application) cached(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next(w, r)
+			return
+		}
+
+		// taken before the read, so a write during the read only costs the client one more full response
+		etag, modified := app.data.current()
+		if r.URL.Query().Get("source") == "replay" && app.replay != nil {
+			// replayed epochs are not stored, their reads follow the playback position instead
+			etag, modified = fmt.Sprintf(`W/"replay-%s-%s"`, app.replay.Version(), app.data.boot), app.started.UTC()
+		}
+		h := w.Header()
+		h.Set("ETag", etag)
+		h.Set("Last-Modified", modified.Format(http.TimeFormat))
+		h.Set("Cache-Control", "no-cache")
+		encoder.Vary(h, "Accept") // the same version is sent in every codec
+		if notModified(r, etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// Count successful writes as new versions
+func (app *Application) modifies(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w}
+		next(sw, r)
+		if sw.status == 0 || sw.status < http.StatusMultipleChoices {
+			app.data.touch()
+		}
+	}
+}
+
+// Whether If-None-Match lists the current entity tag (weak comparison)
+func notModified(r *http.Request, etag string) bool {
+	for _, tag := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || (tag != "" && strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/")) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,8 +2,9 @@ package api
 
 import (
 	"database/sql"
-	"log"
+	"fmt"
 	"os"
+	"strings"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/pelletier/go-toml/v2"
@@ -11,16 +12,31 @@ import (
 
 // Full settings
 type Config struct {
-	Server    ServerConfig   `toml:"server"`
-	Database  DatabaseConfig `toml:"database"`
-	Sql       SqlSettings    `toml:"sql"`
-	Endpoints EndpointConfig `toml:"endpoints"`
+	Server    ServerConfig    `toml:"server"`
+	Database  DatabaseConfig  `toml:"database"`
+	Sql       SqlSettings     `toml:"sql"`
+	Endpoints EndpointConfig  `toml:"endpoints"`
+	Replay    ReplayConfig    `toml:"replay"`
+	Generator GeneratorConfig `toml:"generator"`
+	Health    HealthConfig    `toml:"health"`
+	Geometry  GeometryConfig  `toml:"geometry"`
+	Integrity IntegrityConfig `toml:"integrity"`
+	Slips     SlipsConfig     `toml:"slips"`
+	Logging   LoggingConfig   `toml:"logging"`
+	Auth      AuthConfig      `toml:"auth"`
 }
 
 // Server settings
 type ServerConfig struct {
-	Host string `toml:"host"`
-	Port int    `toml:"port"`
+	Host              string  `toml:"host"`
+	Port              int     `toml:"port"`
+	CertFile          string  `toml:"cert_file"`
+	KeyFile           string  `toml:"key_file"`
+	ClientCaFile      string  `toml:"client_ca_file"`
+	RequireClientCert bool    `toml:"require_client_cert"`
+	ReloadInterval    float64 `toml:"reload_interval"`
+	GrpcPort          int     `toml:"grpc_port"`
+	Compression       bool    `toml:"compression"`
 }
 
 // Database settings
@@ -35,6 +51,9 @@ type SqlSettings struct {
 	NavigationCmds string `toml:"navigation_cmds"`
 	SatelliteCmds  string `toml:"satellite_cmds"`
 	TelemetryCmds  string `toml:"telemetry_cmds"`
+	StatsCmds      string `toml:"stats_cmds"`
+	TruthCmds      string `toml:"truth_cmds"`
+	SlipCmds       string `toml:"slip_cmds"`
 }
 
 // Endpoint settings
@@ -47,6 +66,92 @@ type EndpointConfig struct {
 	Read       string `toml:"read"`
 	Update     string `toml:"update"`
 	Delete     string `toml:"delete"`
+	Stream     string `toml:"stream"`
+	Batch      string `toml:"batch"`
+	Replay     string `toml:"replay"`
+	Stats      string `toml:"stats"`
+	Accuracy   string `toml:"accuracy"`
+	Truth      string `toml:"truth"`
+	Slips      string `toml:"slips"`
+}
+
+// Replay settings
+type ReplayConfig struct {
+	Dir      string  `toml:"dir"`
+	File     string  `toml:"file"`
+	Speed    float64 `toml:"speed"`
+	Loop     bool    `toml:"loop"`
+	Autoplay bool    `toml:"autoplay"`
+}
+
+// Health check settings
+type HealthConfig struct {
+	MaxIngestAge  float64 `toml:"max_ingest_age"`
+	ShutdownDelay float64 `toml:"shutdown_delay"`
+}
+
+// Satellite geometry check settings
+type GeometryConfig struct {
+	Check         bool    `toml:"check"`
+	Fill          bool    `toml:"fill"`
+	AzElTolerance float64 `toml:"azel_tolerance"`
+	DopTolerance  float64 `toml:"dop_tolerance"`
+}
+
+// Integrity (RAIM) monitor settings
+type IntegrityConfig struct {
+	Monitor bool    `toml:"monitor"`
+	Sigma   float64 `toml:"sigma"`
+	Pfa     float64 `toml:"pfa"`
+	Pmd     float64 `toml:"pmd"`
+	Hal     float64 `toml:"hal"`
+	Val     float64 `toml:"val"`
+	Mask    float64 `toml:"mask"`
+}
+
+// Cycle slip detector settings
+type SlipsConfig struct {
+	Detect        bool    `toml:"detect"`
+	Threshold     float64 `toml:"threshold"`
+	LockThreshold float64 `toml:"lock_threshold"`
+	MaxGap        float64 `toml:"max_gap"`
+	AdrSign       float64 `toml:"adr_sign"`
+}
+
+// Authentication settings
+type AuthConfig struct {
+	Enabled    bool           `toml:"enabled"`
+	PublicRead bool           `toml:"public_read"`
+	Keys       []ApiKeyConfig `toml:"keys"`
+}
+
+// Api key settings
+type ApiKeyConfig struct {
+	Name string `toml:"name"`
+	Key  string `toml:"key"`
+	Role string `toml:"role"`
+}
+
+// Logging settings
+type LoggingConfig struct {
+	Format string            `toml:"format"`
+	Level  string            `toml:"level"`
+	Levels map[string]string `toml:"levels"`
+}
+
+// Synthetic telemetry generator settings
+type GeneratorConfig struct {
+	Enabled       bool    `toml:"enabled"`
+	Rate          float64 `toml:"rate"`
+	Latitude      float64 `toml:"latitude"`
+	Longitude     float64 `toml:"longitude"`
+	Altitude      float64 `toml:"altitude"`
+	Speed         float64 `toml:"speed"`
+	Heading       float64 `toml:"heading"`
+	TurnRate      float64 `toml:"turn_rate"`
+	ElevationMask float64 `toml:"elevation_mask"`
+	Gps           bool    `toml:"gps"`
+	Galileo       bool    `toml:"galileo"`
 }
 
 // ParseSettings
@@ -56,31 +161,56 @@ func parseSettings(filename string) (Config, error) {
 	// read file
 	data, err := os.ReadFile(filename)
 	if err != nil {
-		log.Printf("Error reading TOML file: %s", err.Error())
+		logger.Error("Error reading TOML file!", "file", filename, "error", err)
 		return Config{}, err
 	}
 
 	// parse toml data
 	err = toml.Unmarshal(data, &cfg)
 	if err != nil {
-		log.Printf("Error unmarshaling TOML data: %s", err.Error())
+		logger.Error("Error unmarshaling TOML data!", "file", filename, "error", err)
 		return Config{}, err
 	}
 
-	// print config
-	log.Printf("\n[server]\n host = %s\n port = %d\n"+
+	return cfg, nil
+}
+
+// Settings in toml layout (for the startup log)
+func (cfg *Config) String() string {
+	return fmt.Sprintf("\n[server]\n host = %s\n port = %d\n cert_file = %s\n key_file = %s\n client_ca_file = %s\n "+
+		"require_client_cert = %t\n reload_interval = %g\n grpc_port = %d\n compression = %t\n"+
 		"\n[database]\n db_file = %s\n max_size = %d\n clear = %t\n"+
-		"\n[sql]\n navigation_cmds = %s\n satellite_cmds = %s\n telemetry_cmds = %s\n"+
+		"\n[sql]\n navigation_cmds = %s\n satellite_cmds = %s\n telemetry_cmds = %s\n stats_cmds = %s\n truth_cmds = %s\n slip_cmds = %s\n"+
 		"\n[endpoints]\n gui = %s\n navigation = %s\n satellite = %s\n telemetry = %s\n "+
-		"create = %s\n read = %s\n update = %s\n delete = %s\n\n",
+		"create = %s\n read = %s\n update = %s\n delete = %s\n stream = %s\n batch = %s\n replay = %s\n stats = %s\n "+
+		"accuracy = %s\n truth = %s\n slips = %s\n"+
+		"\n[replay]\n dir = %s\n file = %s\n speed = %g\n loop = %t\n autoplay = %t\n"+
+		"\n[generator]\n enabled = %t\n rate = %g\n latitude = %g\n longitude = %g\n altitude = %g\n speed = %g\n "+
+		"heading = %g\n turn_rate = %g\n elevation_mask = %g\n gps = %t\n galileo = %t\n"+
+		"\n[health]\n max_ingest_age = %g\n shutdown_delay = %g\n"+
+		"\n[geometry]\n check = %t\n fill = %t\n azel_tolerance = %g\n dop_tolerance = %g\n"+
+		"\n[integrity]\n monitor = %t\n sigma = %g\n pfa = %g\n pmd = %g\n hal = %g\n val = %g\n mask = %g\n"+
+		"\n[slips]\n detect = %t\n threshold = %g\n lock_threshold = %g\n max_gap = %g\n adr_sign = %g\n"+
+		"\n[logging]\n format = %s\n level = %s\n levels = %v\n"+
+		"\n[auth]\n enabled = %t\n public_read = %t\n keys = %s\n",
 		cfg.Server.Host,
 		cfg.Server.Port,
+		cfg.Server.CertFile,
+		cfg.Server.KeyFile,
+		cfg.Server.ClientCaFile,
+		cfg.Server.RequireClientCert,
+		cfg.Server.ReloadInterval,
+		cfg.Server.GrpcPort,
+		cfg.Server.Compression,
 		cfg.Database.DbFile,
 		cfg.Database.MaxSize,
 		cfg.Database.Clear,
 		cfg.Sql.NavigationCmds,
 		cfg.Sql.SatelliteCmds,
 		cfg.Sql.TelemetryCmds,
+		cfg.Sql.StatsCmds,
+		cfg.Sql.TruthCmds,
+		cfg.Sql.SlipCmds,
 		cfg.Endpoints.Gui,
 		cfg.Endpoints.Navigation,
 		cfg.Endpoints.Satellite,
@@ -88,34 +218,90 @@ func parseSettings(filename string) (Config, error) {
 		cfg.Endpoints.Create,
 		cfg.Endpoints.Read,
 		cfg.Endpoints.Update,
-		cfg.Endpoints.Delete)
+		cfg.Endpoints.Delete,
+		cfg.Endpoints.Stream,
+		cfg.Endpoints.Batch,
+		cfg.Endpoints.Replay,
+		cfg.Endpoints.Stats,
+		cfg.Endpoints.Accuracy,
+		cfg.Endpoints.Truth,
+		cfg.Endpoints.Slips,
+		cfg.Replay.Dir,
+		cfg.Replay.File,
+		cfg.Replay.Speed,
+		cfg.Replay.Loop,
+		cfg.Replay.Autoplay,
+		cfg.Generator.Enabled,
+		cfg.Generator.Rate,
+		cfg.Generator.Latitude,
+		cfg.Generator.Longitude,
+		cfg.Generator.Altitude,
+		cfg.Generator.Speed,
+		cfg.Generator.Heading,
+		cfg.Generator.TurnRate,
+		cfg.Generator.ElevationMask,
+		cfg.Generator.Gps,
+		cfg.Generator.Galileo,
+		cfg.Health.MaxIngestAge,
+		cfg.Health.ShutdownDelay,
+		cfg.Geometry.Check,
+		cfg.Geometry.Fill,
+		cfg.Geometry.AzElTolerance,
+		cfg.Geometry.DopTolerance,
+		cfg.Integrity.Monitor,
+		cfg.Integrity.Sigma,
+		cfg.Integrity.Pfa,
+		cfg.Integrity.Pmd,
+		cfg.Integrity.Hal,
+		cfg.Integrity.Val,
+		cfg.Integrity.Mask,
+		cfg.Slips.Detect,
+		cfg.Slips.Threshold,
+		cfg.Slips.LockThreshold,
+		cfg.Slips.MaxGap,
+		cfg.Slips.AdrSign,
+		cfg.Logging.Format,
+		cfg.Logging.Level,
+		cfg.Logging.Levels,
+		cfg.Auth.Enabled,
+		cfg.Auth.PublicRead,
+		keyNames(cfg.Auth.Keys))
+}
 
-	return cfg, nil
+// Names and roles of the api keys (the keys themselves are never logged)
+func keyNames(keys []ApiKeyConfig) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.Name + " (" + k.Role + ")"
+	}
+	return "[" + strings.Join(names, ", ") + "]"
 }
 
 // InitDatabase
 func initDatabase(db_file string, clear bool) (*sql.DB, error) {
 	// --- initialize database file ---
 	_, err := os.Stat(db_file)
-	if !os.IsNotExist(err) || clear {
-		log.Printf("Removing old '%s' database file ...", db_file)
+	if err == nil && clear {
+		logger.Info("Removing old database file ...", "file", db_file)
 		os.Remove(db_file)
+	}
+	if clear || os.IsNotExist(err) {
 		file, err := os.Create(db_file)
 		if err != nil {
-			log.Printf("Error creating '%s' database file: %s", db_file, err.Error())
+			logger.Error("Error creating database file!", "file", db_file, "error", err)
 			return nil, err
 		}
 		file.Close()
 	}
-	log.Printf("Initialized '%s' database file ...", db_file)
+	logger.Info("Initialized database file ...", "file", db_file)
 
 	// --- open database ---
 	db, err := sql.Open("sqlite3", "file:"+db_file+"?_foreign_keys=on") // open with sqlite
 	if err != nil {
-		log.Printf("Error opening '%s' database file: %s", db_file, err.Error())
+		logger.Error("Error opening database file!", "file", db_file, "error", err)
 		return nil, err
 	}
-	log.Printf("Opened '%s' database file ...", db_file)
+	logger.Info("Opened database file ...", "file", db_file)
 
 	return db, nil
 }
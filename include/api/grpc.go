@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/sturdivant20/sturdr-api/include/logging"
+	"github.com/sturdivant20/sturdr-api/include/rpc"
+	"github.com/sturdivant20/sturdr-api/include/rpc/pb"
+)
+
+var grpcLogger = logging.For("grpc")
+
+// Create the gRPC server (same api keys, roles and certificates as the http server)
+func (app *Application) newGrpcServer(s *rpc.Server) *grpc.Server {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(app.unaryInterceptor),
+		grpc.ChainStreamInterceptor(app.streamInterceptor),
+	}
+	if app.certs != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(app.certs.tlsConfig())))
+	}
+	svr := grpc.NewServer(opts...)
+	pb.RegisterSturdrServiceServer(svr, s)
+	return svr
+}
+
+// Role a method requires, following the http endpoints (EX: "/sturdr.v1.SturdrService/DeleteTelemetry" needs admin)
+func methodRole(full_method string) role {
+	name := full_method[strings.LastIndex(full_method, "/")+1:]
+	switch {
+	case strings.HasPrefix(name, "Create"), name == "Ingest":
+		return roleIngest
+	case strings.HasPrefix(name, "Update"), strings.HasPrefix(name, "Delete"):
+		return roleAdmin
+	}
+	return roleViewer
+}
+
+// Check the client certificate and api key ("authorization: Bearer <key>" or "x-api-key" metadata) of a call
+func (app *Application) authorizeCall(ctx context.Context, full_method string) error {
+	min := methodRole(full_method)
+
+	// 1. client certificate on ingest methods
+	if min == roleIngest && app.cfg.Server.RequireClientCert {
+		p, ok := peer.FromContext(ctx)
+		var info credentials.TLSInfo
+		if ok {
+			info, ok = p.AuthInfo.(credentials.TLSInfo)
+		}
+		if !ok || !verified(&info.State) {
+			return status.Error(codes.Unauthenticated, "client certificate required")
+		}
+	}
+
+	// 2. api key
+	if min = app.required(min); min == rolePublic {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var sent string
+	if v := md.Get("x-api-key"); len(v) > 0 {
+		sent = v[0]
+	} else if v := md.Get("authorization"); len(v) > 0 {
+		sent, _ = strings.CutPrefix(v[0], "Bearer ")
+	}
+	if sent == "" {
+		return status.Error(codes.Unauthenticated, "missing api key")
+	}
+	k, ok := app.authenticate(strings.TrimSpace(sent))
+	if !ok {
+		grpcLogger.WarnContext(ctx, "Rejected unknown api key!", "method", full_method)
+		return status.Error(codes.Unauthenticated, "invalid api key")
+	}
+	if k.role < min {
+		grpcLogger.WarnContext(ctx, "Rejected api key without permission!",
+			"key", k.name, "role", k.role.String(), "required", min.String(), "method", full_method)
+		return status.Errorf(codes.PermissionDenied, "api key '%s' (%s) cannot call this method, %s required", k.name, k.role, min)
+	}
+	return nil
+}
+
+// Tls state with a verified client certificate chain
+func verified(state *tls.ConnectionState) bool {
+	return state != nil && len(state.VerifiedChains) > 0
+}
+
+// Tag, authorize and log every unary call
+func (app *Application) unaryInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+
+	start := time.Now()
+	ctx = logging.WithRequestId(ctx, callId(ctx))
+	res, err := func() (any, error) {
+		if err := app.authorizeCall(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		res, err := handler(ctx, req)
+		if err == nil && methodRole(info.FullMethod) > roleViewer {
+			app.data.touch()
+		}
+		return res, err
+	}()
+	logCall(ctx, info.FullMethod, start, err)
+	return res, err
+}
+
+// Tag, authorize and log every streaming call
+func (app *Application) streamInterceptor(
+	srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	start := time.Now()
+	ctx := logging.WithRequestId(ss.Context(), callId(ss.Context()))
+	err := app.authorizeCall(ctx, info.FullMethod)
+	if err == nil {
+		err = handler(srv, &taggedStream{ServerStream: ss, ctx: ctx})
+	}
+	if err == nil && methodRole(info.FullMethod) > roleViewer {
+		app.data.touch()
+	}
+	logCall(ctx, info.FullMethod, start, err)
+	return err
+}
+
+// Server stream with the tagged context
+type taggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *taggedStream) Context() context.Context {
+	return s.ctx
+}
+
+// Request id of a call (kept from the "x-request-id" metadata if given)
+func callId(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("x-request-id"); len(v) > 0 && v[0] != "" && len(v[0]) <= 64 {
+		return v[0]
+	}
+	return newRequestId()
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	grpcLogger.InfoContext(ctx, "Call",
+		"method", method,
+		"code", status.Code(err).String(),
+		"latency_ms", float64(time.Since(start).Microseconds())/1e3)
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// Build information, set with -ldflags "-X github.com/sturdivant20/sturdr-api/include/api.Version=v1.2.3"
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+// Readiness check result
+type readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Build info datatype
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	Modified  bool   `json:"modified"`
+	Started   string `json:"started"`
+}
+
+// Handle http liveness request (the process is up and serving)
+func (app *Application) healthz(w http.ResponseWriter, r *http.Request) {
+	encoder.WriteText(w, http.StatusOK, "ok")
+}
+
+// Handle http readiness request (the database is usable, its statements are prepared, receiver ingest is recent and
+// the server is not shutting down)
+func (app *Application) readyz(w http.ResponseWriter, r *http.Request) {
+	res := readiness{Status: "ready", Checks: make(map[string]string)}
+	fail := func(check string, msg string) {
+		res.Status = "not ready"
+		res.Checks[check] = msg
+	}
+
+	// 1. graceful shutdown
+	if app.shutdown.Load() {
+		fail("shutdown", "server is shutting down")
+	} else {
+		res.Checks["shutdown"] = "ok"
+	}
+
+	// 2. database connection
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	if err := app.db.PingContext(ctx); err != nil {
+		fail("database", err.Error())
+	} else {
+		res.Checks["database"] = "ok"
+	}
+
+	// 3. sql statements are bound
+	if !app.prepared.Load() {
+		fail("statements", "statements are not prepared")
+	} else {
+		res.Checks["statements"] = "ok"
+	}
+
+	// 4. last receiver epoch, created through the http or grpc ingest or the generator (the server start counts as the
+	// first one, replayed epochs do not count)
+	last := app.started
+	if t := app.ingested.Load(); t > 0 {
+		last = time.Unix(0, t)
+	}
+	age := time.Since(last).Seconds()
+	if limit := app.cfg.Health.MaxIngestAge; limit > 0 && age > limit {
+		fail("ingest", fmt.Sprintf("last ingest %.1f s ago (limit %.1f s)", age, limit))
+	} else {
+		res.Checks["ingest"] = fmt.Sprintf("ok (%.1f s ago)", age)
+	}
+
+	status := http.StatusOK
+	if res.Status != "ready" {
+		status = http.StatusServiceUnavailable
+	}
+	encoder.WriteJson(w, status, res)
+}
+
+// Handle http version request
+func (app *Application) version(w http.ResponseWriter, r *http.Request) {
+	info := buildInfo{Version: Version, Commit: Commit, Started: app.started.UTC().Format(time.RFC3339)}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.GoVersion = bi.GoVersion
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				info.BuildTime = s.Value
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+		if info.Version == "dev" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+	}
+	encoder.WriteJson(w, http.StatusOK, info)
+}
+
+// Record an epoch created by the receiver (a new data version, replayed epochs are not stored and leave it alone)
+func (app *Application) observeReceiver(data *telemetry.Telemetry) {
+	app.ingested.Store(time.Now().UnixNano())
+	app.data.observe(data.Navigation.Sequence)
+	app.receiverGauges(data)
+}
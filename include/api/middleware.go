@@ -0,0 +1,122 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/logging"
+	"github.com/sturdivant20/sturdr-api/include/metrics"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+var httpLogger = logging.For("http")
+
+// Response writer that remembers the status code
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Allow http.ResponseController to reach the underlying writer (for streams)
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Tag, count, time and log every request by its matched route
+func instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		// request id (kept from the client/proxy if given), returned and attached to every log of this request
+		id := r.Header.Get("X-Request-Id")
+		if id == "" || len(id) > 64 {
+			id = newRequestId()
+		}
+		w.Header().Set("X-Request-Id", id)
+		r = r.WithContext(logging.WithRequestId(r.Context(), id))
+
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+
+		// the router fills in the matched pattern
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		if sw.status == 0 {
+			sw.status = http.StatusOK
+		}
+		metrics.HttpRequests.WithLabelValues(route, r.Method, strconv.Itoa(sw.status)).Inc()
+		latency := time.Since(start)
+		metrics.HttpDuration.WithLabelValues(route, r.Method).Observe(latency.Seconds())
+		httpLogger.LogAttrs(r.Context(), slog.LevelInfo, "Request",
+			slog.String("method", r.Method),
+			slog.String("route", route),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", sw.status),
+			slog.Float64("latency_ms", float64(latency.Microseconds())/1e3),
+			slog.String("remote", r.RemoteAddr))
+	})
+}
+
+// Random 16 hex character request id
+func newRequestId() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Update the receiver gauges from an epoch the receiver (or the generator) created (replayed epochs are not reported)
+func (app *Application) receiverGauges(data *telemetry.Telemetry) {
+	app.gauges.Lock()
+	defer app.gauges.Unlock()
+
+	n := &data.Navigation
+	metrics.NSat.Set(float64(n.NSat))
+	metrics.Dop.WithLabelValues("pdop").Set(float64(n.PDOP))
+	metrics.Dop.WithLabelValues("hdop").Set(float64(n.HDOP))
+	metrics.Dop.WithLabelValues("vdop").Set(float64(n.VDOP))
+	metrics.LastEpoch.SetToCurrentTime()
+
+	// per constellation c/no
+	count := make(map[string]int)
+	sum := make(map[string]float64)
+	low := make(map[string]float64)
+	for _, sv := range data.Satellites {
+		c := satellite.Constellation(sv.PRN)
+		if _, ok := low[c]; !ok {
+			low[c] = math.Inf(1)
+		}
+		count[c]++
+		sum[c] += float64(sv.CNo)
+		low[c] = math.Min(low[c], float64(sv.CNo))
+	}
+	metrics.Tracked.Reset()
+	metrics.MeanCNo.Reset()
+	metrics.MinCNo.Reset()
+	for c, k := range count {
+		metrics.Tracked.WithLabelValues(c).Set(float64(k))
+		metrics.MeanCNo.WithLabelValues(c).Set(sum[c] / float64(k))
+		metrics.MinCNo.WithLabelValues(c).Set(low[c])
+	}
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Server certificate and client ca pool, reloaded when their files change
+type certReloader struct {
+	cert_file string
+	key_file  string
+	ca_file   string
+
+	mu     sync.RWMutex
+	config *tls.Config
+	stamps map[string]time.Time // modification time of each file at the last load
+}
+
+// Load the certificate files for the first time
+func newCertReloader(cfg *ServerConfig) (*certReloader, error) {
+	c := &certReloader{cert_file: cfg.CertFile, key_file: cfg.KeyFile, ca_file: cfg.ClientCaFile}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Server tls settings, every handshake uses the latest loaded files
+func (c *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c.mu.RLock()
+			defer c.mu.RUnlock()
+			return c.config, nil
+		},
+	}
+}
+
+// Read and parse the files, the previous settings are kept on error
+func (c *certReloader) load() error {
+	stamps, err := c.modTimes()
+	if err != nil {
+		return err
+	}
+
+	// 1. server certificate
+	cert, err := tls.LoadX509KeyPair(c.cert_file, c.key_file)
+	if err != nil {
+		return err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	// 2. client certificates are verified when sent, endpoints decide whether they are required
+	if c.ca_file != "" {
+		pem, err := os.ReadFile(c.ca_file)
+		if err != nil {
+			return err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in '%s'", c.ca_file)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	c.mu.Lock()
+	c.config, c.stamps = config, stamps
+	c.mu.Unlock()
+	return nil
+}
+
+// Modification time of every configured file
+func (c *certReloader) modTimes() (map[string]time.Time, error) {
+	stamps := make(map[string]time.Time)
+	for _, f := range []string{c.cert_file, c.key_file, c.ca_file} {
+		if f == "" {
+			continue
+		}
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		stamps[f] = info.ModTime()
+	}
+	return stamps, nil
+}
+
+// Poll the files and reload them when any of them changes
+func (c *certReloader) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stamps, err := c.modTimes()
+			if err != nil {
+				continue // files are often replaced in several steps, try again next tick
+			}
+			c.mu.RLock()
+			changed := len(stamps) != len(c.stamps)
+			for f, t := range stamps {
+				changed = changed || !t.Equal(c.stamps[f])
+			}
+			c.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := c.load(); err != nil {
+				logger.Error("Error reloading tls certificates, keeping the previous ones!", "error", err)
+				continue
+			}
+			logger.Info("Reloaded tls certificates ...", "cert_file", c.cert_file, "client_ca_file", c.ca_file)
+		}
+	}
+}
+
+// Wrap a handler so it requires a verified client certificate (when configured)
+func (app *Application) requireClientCert(next http.HandlerFunc) http.HandlerFunc {
+	if !app.cfg.Server.RequireClientCert {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+			logger.WarnContext(r.Context(), "Rejected request without a client certificate!",
+				"remote", r.RemoteAddr, "path", r.URL.Path)
+			http.Error(w, "client certificate required", http.StatusUnauthorized)
+			return
+		}
+		logger.DebugContext(r.Context(), "Accepted client certificate",
+			"subject", r.TLS.VerifiedChains[0][0].Subject.String())
+		next(w, r)
+	}
+}
+
+// Check the tls settings before the server starts
+func checkTls(cfg *ServerConfig) error {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return errors.New("cert_file and key_file must be set together")
+	}
+	if cfg.CertFile == "" && (cfg.ClientCaFile != "" || cfg.RequireClientCert) {
+		return errors.New("client certificates need cert_file and key_file (https)")
+	}
+	if cfg.RequireClientCert && cfg.ClientCaFile == "" {
+		return errors.New("require_client_cert needs client_ca_file")
+	}
+	return nil
+}
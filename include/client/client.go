@@ -0,0 +1,232 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/stats"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+	"github.com/sturdivant20/sturdr-api/include/truth"
+)
+
+// Client of the sturdr REST-API (the default endpoint names from "settings.toml" are assumed)
+type Client struct {
+	BaseURL    string
+	HttpClient *http.Client
+	Format     Format
+	ApiKey     string // sent as "Authorization: Bearer <key>" when set
+}
+
+// Create a client for the server at base_url (EX: "http://localhost:8000")
+func NewClient(base_url string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(base_url, "/"),
+		HttpClient: &http.Client{Timeout: 30 * time.Second},
+		Format:     Json,
+	}
+}
+
+// --- navigation ---
+
+func (c *Client) CreateNavigation(ctx context.Context, n navigation.Navigation) error {
+	return c.write(ctx, http.MethodPost, "/navigation/create", n)
+}
+
+func (c *Client) ReadNavigation(ctx context.Context, q Query) ([]navigation.Navigation, error) {
+	return readAll[navigation.Navigation](c, ctx, "/navigation/read", q)
+}
+
+func (c *Client) UpdateNavigation(ctx context.Context, id int64, n navigation.Navigation) error {
+	return c.write(ctx, http.MethodPost, "/navigation/update/"+strconv.FormatInt(id, 10), n)
+}
+
+func (c *Client) DeleteNavigation(ctx context.Context, id int64) error {
+	return c.write(ctx, http.MethodPost, "/navigation/delete/"+strconv.FormatInt(id, 10), nil)
+}
+
+// --- satellite ---
+
+func (c *Client) CreateSatellite(ctx context.Context, sv satellite.Satellite) error {
+	return c.write(ctx, http.MethodPost, "/satellite/create", sv)
+}
+
+func (c *Client) ReadSatellite(ctx context.Context, q Query) ([]satellite.Satellite, error) {
+	return readAll[satellite.Satellite](c, ctx, "/satellite/read", q)
+}
+
+func (c *Client) UpdateSatellite(ctx context.Context, id int64, sv satellite.Satellite) error {
+	return c.write(ctx, http.MethodPost, "/satellite/update/"+strconv.FormatInt(id, 10), sv)
+}
+
+func (c *Client) DeleteSatellite(ctx context.Context, id int64) error {
+	return c.write(ctx, http.MethodPost, "/satellite/delete/"+strconv.FormatInt(id, 10), nil)
+}
+
+// --- telemetry ---
+
+func (c *Client) CreateTelemetry(ctx context.Context, data telemetry.Telemetry) error {
+	return c.write(ctx, http.MethodPost, "/telemetry/create", &data)
+}
+
+func (c *Client) ReadTelemetry(ctx context.Context, q Query) ([]telemetry.Telemetry, error) {
+	return readAll[telemetry.Telemetry](c, ctx, "/telemetry/read", q)
+}
+
+// Create several epochs in one transaction (all or none are stored)
+func (c *Client) CreateTelemetryBatch(ctx context.Context, data []telemetry.Telemetry) error {
+	return c.write(ctx, http.MethodPost, "/telemetry/batch", data)
+}
+
+func (c *Client) UpdateTelemetry(ctx context.Context, sequence int64, data telemetry.Telemetry) error {
+	return c.write(ctx, http.MethodPost, "/telemetry/update/"+strconv.FormatInt(sequence, 10), &data)
+}
+
+func (c *Client) DeleteTelemetry(ctx context.Context, sequence int64) error {
+	return c.write(ctx, http.MethodPost, "/telemetry/delete/"+strconv.FormatInt(sequence, 10), nil)
+}
+
+// --- stats ---
+
+func (c *Client) ReadStats(ctx context.Context, q Query) (stats.Stats, error) {
+	return readOne[stats.Stats](c, ctx, "/stats", q)
+}
+
+// --- truth ---
+
+// Store reference trajectory epochs (an epoch at an existing time replaces it)
+func (c *Client) ImportTruth(ctx context.Context, data []truth.Truth) error {
+	return c.write(ctx, http.MethodPost, "/truth/import", data)
+}
+
+func (c *Client) ReadTruth(ctx context.Context, q Query) ([]truth.Truth, error) {
+	return readAll[truth.Truth](c, ctx, "/truth/read", q)
+}
+
+// Navigation errors against the reference trajectory
+func (c *Client) CompareTruth(ctx context.Context, q Query) (truth.Comparison, error) {
+	return readOne[truth.Comparison](c, ctx, "/truth/compare", q)
+}
+
+// --- helpers ---
+
+// Send a read request, returning the response body on success
+func (c *Client) read(ctx context.Context, path string, q Query) (io.ReadCloser, error) {
+	res, err := c.do(ctx, http.MethodGet, path, q.values(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// Send a create/update/delete request with an optional body
+func (c *Client) write(ctx context.Context, method string, path string, data any) error {
+	var body io.Reader
+	if data != nil {
+		var buf bytes.Buffer
+		if err := c.encode(&buf, data); err != nil {
+			return err
+		}
+		body = &buf
+	}
+
+	res, err := c.do(ctx, method, path, url.Values{}, body)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, res.Body)
+	return res.Body.Close()
+}
+
+// Send a request, converting unsuccessful responses into a StatusError
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path+"?"+query.Encode(), body)
+	if err != nil {
+		return nil, err
+	}
+	if codec, ok := encoder.Lookup(string(c.Format)); ok {
+		req.Header.Set("Accept", codec.ContentType())
+		if body != nil {
+			req.Header.Set("Content-Type", codec.ContentType())
+		}
+	}
+	if c.ApiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.ApiKey)
+	}
+	res, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		defer res.Body.Close()
+		msg, _ := io.ReadAll(res.Body)
+		return nil, &StatusError{Code: res.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+	return res, nil
+}
+
+// Codec of the client format
+func (c *Client) codec() (encoder.Codec, error) {
+	codec, ok := encoder.Lookup(string(c.Format))
+	if !ok {
+		return nil, fmt.Errorf("sturdr: unknown format '%s'", c.Format)
+	}
+	return codec, nil
+}
+
+// Encode a request body in the client format
+func (c *Client) encode(w io.Writer, data any) error {
+	codec, err := c.codec()
+	if err != nil {
+		return err
+	}
+	return codec.Encode(w, data)
+}
+
+// Read records and decode the response body in the client format
+func readAll[T any](c *Client, ctx context.Context, path string, q Query) ([]T, error) {
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.read(ctx, path, q)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var items []T
+	err = codec.Decode(body, &items)
+	return items, err
+}
+
+// Read a summary and decode the response body in the client format (binary clients receive json, summaries have no
+// binary decoding)
+func readOne[T any](c *Client, ctx context.Context, path string, q Query) (T, error) {
+	var item T
+	sc := *c
+	if sc.Format == Binary {
+		sc.Format = Json
+	}
+	codec, err := sc.codec()
+	if err != nil {
+		return item, err
+	}
+	body, err := sc.read(ctx, path, q)
+	if err != nil {
+		return item, err
+	}
+	defer body.Close()
+
+	err = codec.Decode(body, &item)
+	return item, err
+}
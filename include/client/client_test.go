@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/api"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// The settings, sql and gui paths are relative to the repository root
+func TestMain(m *testing.M) {
+	if err := os.Chdir("../.."); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// Start a server with the default settings on an empty database of its own
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	data, err := os.ReadFile("./config/settings.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	db_line := regexp.MustCompile(`(?m)^db_file *=.*$`)
+	data = db_line.ReplaceAll(data, []byte(`db_file = "`+filepath.Join(dir, "sturdr.db")+`"`))
+	cfg_fname := filepath.Join(dir, "settings.toml")
+	if err := os.WriteFile(cfg_fname, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var app api.Application
+	if err := app.Init(cfg_fname); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(app.Mount())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testNavigation(sequence uint64, tow float32, n_sat uint8) navigation.Navigation {
+	return navigation.Navigation{
+		Sequence: sequence, Week: 2300, ToW: tow, NSat: n_sat,
+		Latitude: 32.5864, Longitude: -85.4944, Altitude: 200, Vn: 1.5, Ve: -0.5, Vd: 0.1,
+		Yaw: 45, PDOP: 1.8, HDOP: 1.0, VDOP: 1.5,
+	}
+}
+
+func testSatellite(sequence uint64, tow float32, prn uint8) satellite.Satellite {
+	return satellite.Satellite{
+		Sequence: sequence, Week: 2300, ToW: tow, PRN: prn,
+		X: 15600e3, Y: 7540e3, Z: 20140e3, Vx: -1.2e3, Vy: 2.1e3, Vz: 0.4e3,
+		Doppler: -1250.5, PSR: 21.2e6, ADR: 1.1e5, Azimuth: 120, Elevation: 45, CNo: 42,
+		IE: 900, IP: 1800, IL: 850, QE: 10, QP: 20, QL: -5,
+	}
+}
+
+var formats = []Format{Json, Binary}
+
+func TestNavigationCrud(t *testing.T) {
+	for _, format := range formats {
+		t.Run(string(format), func(t *testing.T) {
+			c := NewClient(newTestServer(t).URL)
+			c.Format = format
+			ctx := context.Background()
+
+			// 1. create
+			for i := uint64(1); i <= 3; i++ {
+				if err := c.CreateNavigation(ctx, testNavigation(i, float32(i), 0)); err != nil {
+					t.Fatalf("create %d: %v", i, err)
+				}
+			}
+
+			// 2. read a span and the latest epoch
+			items, err := c.ReadNavigation(ctx, Since(2300, 2))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 2 || items[0] != testNavigation(2, 2, 0) || items[1] != testNavigation(3, 3, 0) {
+				t.Fatalf("read since tow 2: got %+v", items)
+			}
+			items, err = c.ReadNavigation(ctx, Latest())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 1 || items[0].Sequence != 3 {
+				t.Fatalf("read latest: got %+v", items)
+			}
+
+			// 3. update
+			n := testNavigation(2, 2, 0)
+			n.Altitude = 250
+			if err := c.UpdateNavigation(ctx, 2, n); err != nil {
+				t.Fatal(err)
+			}
+			if items, err = c.ReadNavigation(ctx, Since(2300, 2)); err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 2 || items[0] != n {
+				t.Fatalf("read after update: got %+v", items)
+			}
+
+			// 4. delete
+			if err := c.DeleteNavigation(ctx, 3); err != nil {
+				t.Fatal(err)
+			}
+			if items, err = c.ReadNavigation(ctx, Since(2300, 0)); err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 2 {
+				t.Fatalf("read after delete: got %d epochs, want 2", len(items))
+			}
+		})
+	}
+}
+
+func TestSatelliteCrud(t *testing.T) {
+	for _, format := range formats {
+		t.Run(string(format), func(t *testing.T) {
+			c := NewClient(newTestServer(t).URL)
+			c.Format = format
+			ctx := context.Background()
+
+			// 1. create (satellites belong to a navigation epoch)
+			if err := c.CreateNavigation(ctx, testNavigation(1, 10, 2)); err != nil {
+				t.Fatal(err)
+			}
+			for _, prn := range []uint8{5, 12} {
+				if err := c.CreateSatellite(ctx, testSatellite(1, 10, prn)); err != nil {
+					t.Fatalf("create prn %d: %v", prn, err)
+				}
+			}
+
+			// 2. read every prn and a single prn
+			items, err := c.ReadSatellite(ctx, Since(2300, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 2 {
+				t.Fatalf("read since: got %d satellites, want 2", len(items))
+			}
+			items, err = c.ReadSatellite(ctx, Latest().PRN(12))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 1 || items[0] != testSatellite(1, 10, 12) {
+				t.Fatalf("read latest prn 12: got %+v", items)
+			}
+
+			// 3. update (by table row, the first satellite is row 1)
+			sv := testSatellite(1, 10, 5)
+			sv.CNo = 30
+			if err := c.UpdateSatellite(ctx, 1, sv); err != nil {
+				t.Fatal(err)
+			}
+			if items, err = c.ReadSatellite(ctx, Since(2300, 0).PRN(5)); err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 1 || items[0] != sv {
+				t.Fatalf("read after update: got %+v", items)
+			}
+
+			// 4. delete
+			if err := c.DeleteSatellite(ctx, 1); err != nil {
+				t.Fatal(err)
+			}
+			if items, err = c.ReadSatellite(ctx, Since(2300, 0)); err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 1 || items[0].PRN != 12 {
+				t.Fatalf("read after delete: got %+v", items)
+			}
+		})
+	}
+}
+
+func TestTelemetryCrud(t *testing.T) {
+	for _, format := range formats {
+		t.Run(string(format), func(t *testing.T) {
+			c := NewClient(newTestServer(t).URL)
+			c.Format = format
+			ctx := context.Background()
+			epoch := func(sequence uint64, tow float32) telemetry.Telemetry {
+				return telemetry.Telemetry{
+					Navigation: testNavigation(sequence, tow, 2),
+					Satellites: []satellite.Satellite{testSatellite(sequence, tow, 5), testSatellite(sequence, tow, 12)},
+				}
+			}
+
+			// 1. create one epoch and a batch
+			if err := c.CreateTelemetry(ctx, epoch(1, 1)); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.CreateTelemetryBatch(ctx, []telemetry.Telemetry{epoch(2, 2), epoch(3, 3)}); err != nil {
+				t.Fatal(err)
+			}
+
+			// 2. read
+			items, err := c.ReadTelemetry(ctx, Since(2300, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 3 {
+				t.Fatalf("read since: got %d epochs, want 3", len(items))
+			}
+			for i, data := range items {
+				want := epoch(uint64(i+1), float32(i+1))
+				if data.Navigation != want.Navigation || len(data.Satellites) != 2 ||
+					data.Satellites[0] != want.Satellites[0] || data.Satellites[1] != want.Satellites[1] {
+					t.Fatalf("read epoch %d: got %+v", i+1, data)
+				}
+			}
+
+			// 3. update an epoch (its satellites are matched by prn, the other epochs are left alone)
+			up := epoch(2, 2)
+			up.Navigation.Latitude = 33
+			up.Satellites[1].CNo = 30
+			if err := c.UpdateTelemetry(ctx, 2, up); err != nil {
+				t.Fatal(err)
+			}
+			items, err = c.ReadTelemetry(ctx, Since(2300, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 3 || items[1].Navigation != up.Navigation || items[1].Satellites[1] != up.Satellites[1] ||
+				items[0].Satellites[1] != epoch(1, 1).Satellites[1] {
+				t.Fatalf("read after update: got %+v", items)
+			}
+
+			// 4. delete (its satellites go with it)
+			if err := c.DeleteTelemetry(ctx, 3); err != nil {
+				t.Fatal(err)
+			}
+			if items, err = c.ReadTelemetry(ctx, Since(2300, 3)); err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 0 {
+				t.Fatalf("read after delete: got %d epochs, want 0", len(items))
+			}
+		})
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	c := NewClient(newTestServer(t).URL + "/missing")
+	_, err := c.ReadNavigation(context.Background(), Latest())
+	var se *StatusError
+	if !errors.As(err, &se) || se.Code != http.StatusNotFound {
+		t.Fatalf("read of an unknown endpoint: got %v, want a 404 status error", err)
+	}
+}
+
+func TestQueryValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  string
+	}{
+		{"latest", Latest(), ""},
+		{"latest prn", Latest().PRN(7), "prn=7"},
+		{"since", Since(2352, 507440.5), "tow=507440.5&week=2352"},
+		{"since prn", Since(2352, 0).PRN(0), "prn=0&tow=0&week=2352"},
+		{"until", Since(2352, 10).Until(2352, 20), "end_tow=20&end_week=2352&tow=10&week=2352"},
+		{"gap", Latest().Gap(1.5), "gap=1.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.values().Encode(); got != tt.want {
+				t.Errorf("got '%s', want '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	c := NewClient(newTestServer(t).URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := c.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the stream is subscribed once its headers arrive, so a created epoch is published to it
+	want := telemetry.Telemetry{
+		Navigation: testNavigation(1, 42, 1),
+		Satellites: []satellite.Satellite{testSatellite(1, 42, 5)},
+	}
+	if err := c.CreateTelemetry(ctx, want); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got, ok := <-sub.C:
+		if !ok {
+			t.Fatalf("stream closed: %v", sub.Err())
+		}
+		if got.Navigation != want.Navigation || len(got.Satellites) != 1 || got.Satellites[0] != want.Satellites[0] {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no epoch received")
+	}
+
+	cancel()
+	for range sub.C {
+	}
+	if err := sub.Err(); err != nil {
+		t.Fatalf("stream ended with %v after cancel", err)
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// Live telemetry subscription
+type Subscription struct {
+	C    <-chan telemetry.Telemetry // closed when the stream ends
+	err  error
+	done chan struct{}
+}
+
+// Reason the stream ended (nil if the context was cancelled), only valid after C is closed
+func (s *Subscription) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Subscribe to the live telemetry stream until the context is cancelled
+func (c *Client) Subscribe(ctx context.Context) (*Subscription, error) {
+	// streams are long-lived, so the client timeout cannot be used
+	hc := *c.HttpClient
+	hc.Timeout = 0
+	stream := &Client{BaseURL: c.BaseURL, HttpClient: &hc, Format: Json, ApiKey: c.ApiKey}
+
+	res, err := stream.do(ctx, http.MethodGet, "/telemetry/stream", url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan telemetry.Telemetry, 16)
+	sub := &Subscription{C: ch, done: make(chan struct{})}
+	go func() {
+		defer close(sub.done)
+		defer close(ch)
+		defer res.Body.Close()
+
+		// server-sent events, only "data:" lines carry telemetry
+		scanner := bufio.NewScanner(res.Body)
+		scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+		for scanner.Scan() {
+			line, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			var data telemetry.Telemetry
+			if err := json.Unmarshal([]byte(strings.TrimSpace(line)), &data); err != nil {
+				sub.err = err
+				return
+			}
+			select {
+			case ch <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() == nil {
+			sub.err = scanner.Err()
+		}
+	}()
+
+	return sub, nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Wire format used for request and response bodies (any "format" alias registered in the encoder package)
+type Format string
+
+const (
+	Json    Format = "json"
+	Binary  Format = "binary"
+	Msgpack Format = "msgpack"
+	Cbor    Format = "cbor"
+)
+
+// Error returned by the server
+type StatusError struct {
+	Code    int
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("sturdr: %d %s", e.Code, e.Message)
+}
+
+// Read query, built with Latest or Since
+type Query struct {
+	week     uint16
+	tow      float32
+	since    bool
+	prn      int
+	end_week uint16
+	end_tow  float32
+	until    bool
+	gap      float64
+}
+
+// Query only the latest epoch
+func Latest() Query {
+	return Query{prn: -1}
+}
+
+// Query every epoch at or after a gps week and time of week
+func Since(week uint16, tow float32) Query {
+	return Query{week: week, tow: tow, since: true, prn: -1}
+}
+
+// Restrict a satellite query to a single prn
+func (q Query) PRN(prn uint8) Query {
+	q.prn = int(prn)
+	return q
+}
+
+// End the span of a stats query at a gps week and time of week
+func (q Query) Until(week uint16, tow float32) Query {
+	q.end_week, q.end_tow, q.until = week, tow, true
+	return q
+}
+
+// Longest gap inside a tracking arc (stats) or interpolated truth gap (truth comparison) [s]
+func (q Query) Gap(seconds float64) Query {
+	q.gap = seconds
+	return q
+}
+
+// Encode the query parameters
+func (q Query) values() url.Values {
+	v := url.Values{}
+	if q.since {
+		v.Set("week", strconv.FormatUint(uint64(q.week), 10))
+		v.Set("tow", strconv.FormatFloat(float64(q.tow), 'f', -1, 32))
+	}
+	if q.prn >= 0 {
+		v.Set("prn", strconv.Itoa(q.prn))
+	}
+	if q.until {
+		v.Set("end_week", strconv.FormatUint(uint64(q.end_week), 10))
+		v.Set("end_tow", strconv.FormatFloat(float64(q.end_tow), 'f', -1, 32))
+	}
+	if q.gap > 0 {
+		v.Set("gap", strconv.FormatFloat(q.gap, 'f', -1, 64))
+	}
+	return v
+}
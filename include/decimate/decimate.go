@@ -0,0 +1,335 @@
+package decimate
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Downsampling of long histories (EX: an hour of 50 Hz data for a browser plot), at most one mode per request
+type Options struct {
+	Every  int     // keep every n-th record
+	Bucket float64 // aggregate fixed time buckets [s]
+	Agg    string  // "mean", "min" or "max" of every bucket
+	Points int     // target number of points of a shape preserving (largest triangle three buckets) downsample
+	By     string  // numeric field whose shape is preserved (EX: "cno")
+}
+
+// Parse "every=", "bucket=" with "agg=", or "points=" with "by=" (the default field when not given)
+func ParseOptions(q url.Values, default_by string) (Options, error) {
+	o := Options{Agg: "mean", By: default_by}
+	modes := 0
+	if s := q.Get("every"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return Options{}, fmt.Errorf("every must be a positive integer, got '%s'", s)
+		}
+		o.Every = n
+		modes++
+	}
+	if s := q.Get("bucket"); s != "" {
+		b, err := strconv.ParseFloat(s, 64)
+		if err != nil || !(b > 0) || math.IsInf(b, 1) {
+			return Options{}, fmt.Errorf("bucket must be a positive number of seconds, got '%s'", s)
+		}
+		o.Bucket = b
+		modes++
+	}
+	if s := q.Get("agg"); s != "" {
+		if s != "mean" && s != "min" && s != "max" {
+			return Options{}, fmt.Errorf("agg must be 'mean', 'min' or 'max', got '%s'", s)
+		}
+		o.Agg = s
+	}
+	if s := q.Get("points"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 3 {
+			return Options{}, fmt.Errorf("points must be an integer of at least 3, got '%s'", s)
+		}
+		o.Points = n
+		modes++
+	}
+	if s := q.Get("by"); s != "" {
+		o.By = s
+	}
+	if modes > 1 {
+		return Options{}, fmt.Errorf("only one of every, bucket and points can be given")
+	}
+	if q.Get("agg") != "" && o.Bucket == 0 {
+		return Options{}, fmt.Errorf("agg needs bucket")
+	}
+	if q.Get("by") != "" && o.Points == 0 {
+		return Options{}, fmt.Errorf("by needs points")
+	}
+	return o, nil
+}
+
+// Whether any downsampling was requested
+func (o Options) Enabled() bool {
+	return o.Every > 1 || o.Bucket > 0 || o.Points > 0
+}
+
+// Downsample a time ordered series (at is the time of a record in seconds)
+func Series[T any](items []T, at func(*T) float64, o Options) ([]T, error) {
+	switch {
+	case o.Every > 1:
+		out := make([]T, 0, (len(items)+o.Every-1)/o.Every)
+		for i := 0; i < len(items); i += o.Every {
+			out = append(out, items[i])
+		}
+		return out, nil
+	case o.Bucket > 0:
+		if name, ok := nestedSlice(reflect.TypeFor[T]()); ok {
+			return nil, fmt.Errorf("bucket cannot aggregate the nested '%s' records, use every or points", name)
+		}
+		return buckets(items, at, o.Bucket, o.Agg), nil
+	case o.Points > 0:
+		y, err := numeric[T](o.By)
+		if err != nil {
+			return nil, err
+		}
+		return lttb(items, at, y, o.Points), nil
+	}
+	return items, nil
+}
+
+// Downsample every group separately (EX: one series per prn), the result is ordered by time
+func Grouped[T any, K comparable](items []T, key func(*T) K, at func(*T) float64, o Options) ([]T, error) {
+	if !o.Enabled() {
+		return items, nil
+	}
+	groups := make(map[K][]T)
+	var order []K
+	for i := range items {
+		k := key(&items[i])
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], items[i])
+	}
+	out := make([]T, 0, len(items))
+	for _, k := range order {
+		g, err := Series(groups[k], at, o)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, g...)
+	}
+	slices.SortStableFunc(out, func(a, b T) int { return cmp.Compare(at(&a), at(&b)) })
+	return out, nil
+}
+
+// --- fixed time buckets ---
+
+// One record per bucket of gps time (week*604800 + tow, so buckets run across week rollovers): the first record of the
+// bucket with its float fields replaced by their mean, min or max (its time and identity fields are kept)
+func buckets[T any](items []T, at func(*T) float64, width float64, agg string) []T {
+	fields := floatFields(reflect.TypeFor[T](), nil)
+	var out []T
+	for i := 0; i < len(items); {
+		k := math.Floor(at(&items[i]) / width)
+		j := i + 1
+		for j < len(items) && math.Floor(at(&items[j])/width) == k {
+			j++
+		}
+		out = append(out, aggregate(items[i:j], fields, agg))
+		i = j
+	}
+	return out
+}
+
+// Aggregate of every float field over the records where it has a value (an optional field stays nil without any)
+func aggregate[T any](items []T, fields []field, agg string) T {
+	rec := items[0]
+	v := reflect.ValueOf(&rec).Elem()
+	for _, p := range fields {
+		acc, sin, cos, n := 0.0, 0.0, 0.0, 0
+		negative := false
+		for k := range items {
+			x := reflect.ValueOf(&items[k]).Elem().FieldByIndex(p.index)
+			if x.Kind() == reflect.Pointer {
+				if x.IsNil() {
+					continue
+				}
+				x = x.Elem()
+			}
+			switch {
+			case agg == "mean" && p.angle:
+				sin += math.Sin(x.Float() * math.Pi / 180.0)
+				cos += math.Cos(x.Float() * math.Pi / 180.0)
+				negative = negative || x.Float() < 0
+			case n == 0:
+				acc = x.Float()
+			case agg == "min":
+				acc = math.Min(acc, x.Float())
+			case agg == "max":
+				acc = math.Max(acc, x.Float())
+			default:
+				acc += x.Float()
+			}
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		switch {
+		case agg == "mean" && p.angle:
+			// mean direction, in [0, 360) unless the records use (-180, 180]
+			acc = math.Atan2(sin, cos) * 180.0 / math.Pi
+			if acc < 0 && !negative {
+				acc += 360.0
+			}
+		case agg == "mean":
+			acc /= float64(n)
+		}
+		f := v.FieldByIndex(p.index)
+		if f.Kind() == reflect.Pointer {
+			f.Set(reflect.New(f.Type().Elem())) // a new value, the first record still points at its own
+			f = f.Elem()
+		}
+		f.SetFloat(acc)
+	}
+	return rec
+}
+
+// Fields that place a record in time or name it, a bucket keeps them from its first record
+var identity = map[string]bool{"week": true, "tow": true, "sequence": true, "prn": true}
+
+// Angles [deg] that wrap around, a bucket mean averages them on the circle (EX: 359 and 1 average to 0, not 180)
+var angles = map[string]bool{"yaw": true, "roll": true, "azimuth": true, "longitude": true}
+
+// Aggregated float field of a record
+type field struct {
+	index []int // path through the nested structs
+	angle bool
+}
+
+// Aggregated float (and optional float) fields of a struct (and its nested structs)
+func floatFields(t reflect.Type, index []int) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		path := append(append([]int(nil), index...), i)
+		kind := f.Type.Kind()
+		if kind == reflect.Pointer {
+			kind = f.Type.Elem().Kind()
+		}
+		switch {
+		case kind == reflect.Float32, kind == reflect.Float64:
+			if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); !identity[tag] {
+				fields = append(fields, field{index: path, angle: angles[tag]})
+			}
+		case f.Type.Kind() == reflect.Struct:
+			fields = append(fields, floatFields(f.Type, path)...)
+		}
+	}
+	return fields
+}
+
+// Json name of the first slice field of a struct (and its nested structs), its records can't be aggregated
+func nestedSlice(t reflect.Type) (string, bool) {
+	for i := 0; t.Kind() == reflect.Struct && i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch f.Type.Kind() {
+		case reflect.Slice, reflect.Array:
+			return tag, true
+		case reflect.Struct:
+			if name, ok := nestedSlice(f.Type); ok {
+				return tag + "." + name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// --- largest triangle three buckets ---
+
+// Keep the first and last record, and from each of n-2 buckets the record forming the largest triangle with the
+// previously kept record and the average of the next bucket
+func lttb[T any](items []T, x, y func(*T) float64, n int) []T {
+	if n >= len(items) {
+		return items
+	}
+	out := make([]T, 0, n)
+	out = append(out, items[0])
+	width := float64(len(items)-2) / float64(n-2)
+	a := 0
+	for i := 0; i < n-2; i++ {
+		// 1. average of the next bucket (the last record after the final bucket)
+		lo := int(float64(i+1)*width) + 1
+		hi := min(int(float64(i+2)*width)+1, len(items))
+		if hi <= lo {
+			lo, hi = len(items)-1, len(items)
+		}
+		var avg_x, avg_y float64
+		for j := lo; j < hi; j++ {
+			avg_x += x(&items[j])
+			avg_y += y(&items[j])
+		}
+		avg_x /= float64(hi - lo)
+		avg_y /= float64(hi - lo)
+
+		// 2. largest triangle in this bucket
+		ax, ay := x(&items[a]), y(&items[a])
+		best, best_area := -1, -1.0
+		for j := int(float64(i)*width) + 1; j < int(float64(i+1)*width)+1; j++ {
+			area := math.Abs((ax-avg_x)*(y(&items[j])-ay) - (ax-x(&items[j]))*(avg_y-ay))
+			if area > best_area {
+				best, best_area = j, area
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		out = append(out, items[best])
+		a = best
+	}
+	return append(out, items[len(items)-1])
+}
+
+// Value of a numeric field by json name (nested records as "navigation.altitude")
+func numeric[T any](name string) (func(*T) float64, error) {
+	t := reflect.TypeFor[T]()
+	var index []int
+	for _, part := range strings.Split(name, ".") {
+		found := false
+		for i := 0; t.Kind() == reflect.Struct && i < t.NumField(); i++ {
+			tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if tag == part {
+				index = append(index, i)
+				t = t.Field(i).Type
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown field '%s'", name)
+		}
+	}
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return func(rec *T) float64 { return reflect.ValueOf(rec).Elem().FieldByIndex(index).Float() }, nil
+	case reflect.Pointer:
+		if k := t.Elem().Kind(); k == reflect.Float32 || k == reflect.Float64 {
+			// optional values without one count as 0 in the shape
+			return func(rec *T) float64 {
+				v := reflect.ValueOf(rec).Elem().FieldByIndex(index)
+				if v.IsNil() {
+					return 0
+				}
+				return v.Elem().Float()
+			}, nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(rec *T) float64 { return float64(reflect.ValueOf(rec).Elem().FieldByIndex(index).Uint()) }, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(rec *T) float64 { return float64(reflect.ValueOf(rec).Elem().FieldByIndex(index).Int()) }, nil
+	}
+	return nil, fmt.Errorf("field '%s' is not numeric", name)
+}
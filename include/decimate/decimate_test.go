@@ -0,0 +1,246 @@
+package decimate
+
+import (
+	"math"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type record struct {
+	Week     uint16   `json:"week"`
+	ToW      float64  `json:"tow"`
+	PRN      uint8    `json:"prn"`
+	CNo      float32  `json:"cno"`
+	Yaw      float32  `json:"yaw"`
+	Multi    *float32 `json:"multipath"`
+	Sequence uint64   `json:"sequence"`
+}
+
+func at(r *record) float64 { return float64(r.Week)*604800.0 + r.ToW }
+
+func ptr(v float32) *float32 { return &v }
+
+func TestParseOptions(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Options
+		err   string
+	}{
+		{"", Options{Agg: "mean", By: "cno"}, ""},
+		{"every=5", Options{Every: 5, Agg: "mean", By: "cno"}, ""},
+		{"bucket=2.5&agg=max", Options{Bucket: 2.5, Agg: "max", By: "cno"}, ""},
+		{"points=100&by=yaw", Options{Points: 100, Agg: "mean", By: "yaw"}, ""},
+		{"every=0", Options{}, "every must be a positive integer"},
+		{"every=x", Options{}, "every must be a positive integer"},
+		{"bucket=-1", Options{}, "bucket must be a positive number"},
+		{"bucket=inf", Options{}, "bucket must be a positive number"},
+		{"bucket=NaN", Options{}, "bucket must be a positive number"},
+		{"bucket=1&agg=median", Options{}, "agg must be"},
+		{"points=2", Options{}, "points must be an integer of at least 3"},
+		{"every=2&bucket=1", Options{}, "only one of every, bucket and points"},
+		{"bucket=1&points=10", Options{}, "only one of every, bucket and points"},
+		{"agg=min", Options{}, "agg needs bucket"},
+		{"every=2&agg=max", Options{}, "agg needs bucket"},
+		{"by=cno", Options{}, "by needs points"},
+		{"bucket=1&by=cno", Options{}, "by needs points"},
+	}
+	for _, tt := range tests {
+		q, _ := url.ParseQuery(tt.query)
+		got, err := ParseOptions(q, "cno")
+		switch {
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("%q: error %v, want %q", tt.query, err, tt.err)
+		case tt.err == "" && err != nil:
+			t.Errorf("%q: unexpected error %v", tt.query, err)
+		case tt.err == "" && got != tt.want:
+			t.Errorf("%q: got %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestEvery(t *testing.T) {
+	items := make([]record, 10)
+	for i := range items {
+		items[i].ToW = float64(i)
+	}
+	out, err := Series(items, at, Options{Every: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{0, 3, 6, 9}
+	if len(out) != len(want) {
+		t.Fatalf("got %d records, want %d", len(out), len(want))
+	}
+	for i := range want {
+		if out[i].ToW != want[i] {
+			t.Errorf("record %d: tow %g, want %g", i, out[i].ToW, want[i])
+		}
+	}
+}
+
+func TestBucket(t *testing.T) {
+	items := []record{
+		{Week: 2300, ToW: 10.0, PRN: 3, Sequence: 1, CNo: 40, Yaw: 359, Multi: nil},
+		{Week: 2300, ToW: 10.5, PRN: 3, Sequence: 2, CNo: 44, Yaw: 1, Multi: ptr(0.2)},
+		{Week: 2300, ToW: 10.9, PRN: 3, Sequence: 3, CNo: 42, Yaw: 3, Multi: ptr(0.6)},
+		{Week: 2300, ToW: 11.2, PRN: 3, Sequence: 4, CNo: 30, Yaw: 90, Multi: nil},
+	}
+	tests := []struct {
+		agg   string
+		cno   float32
+		yaw   float32
+		multi float32
+	}{
+		{"mean", 42, 1, 0.4},
+		{"min", 40, 1, 0.2},
+		{"max", 44, 359, 0.6},
+	}
+	for _, tt := range tests {
+		out, err := Series(items, at, Options{Bucket: 1, Agg: tt.agg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out) != 2 {
+			t.Fatalf("%s: got %d buckets, want 2", tt.agg, len(out))
+		}
+		b := out[0]
+		if b.ToW != 10.0 || b.Week != 2300 || b.PRN != 3 || b.Sequence != 1 {
+			t.Errorf("%s: identity fields %+v, want those of the first record", tt.agg, b)
+		}
+		if math.Abs(float64(b.CNo-tt.cno)) > 1e-4 {
+			t.Errorf("%s: cno %g, want %g", tt.agg, b.CNo, tt.cno)
+		}
+		if math.Abs(float64(b.Yaw-tt.yaw)) > 1e-3 {
+			t.Errorf("%s: yaw %g, want %g", tt.agg, b.Yaw, tt.yaw)
+		}
+		if b.Multi == nil || math.Abs(float64(*b.Multi-tt.multi)) > 1e-4 {
+			t.Errorf("%s: multipath %v, want %g", tt.agg, b.Multi, tt.multi)
+		}
+		if out[1].Multi != nil {
+			t.Errorf("%s: multipath of a bucket without values %g, want nil", tt.agg, *out[1].Multi)
+		}
+	}
+
+	// the records keep their own optional values
+	if *items[1].Multi != 0.2 {
+		t.Errorf("aggregate changed the input record, multipath %g", *items[1].Multi)
+	}
+}
+
+func TestBucketAngles(t *testing.T) {
+	tests := []struct {
+		yaw  []float32
+		want float32
+	}{
+		{[]float32{359, 1}, 0},
+		{[]float32{350, 20}, 5},
+		{[]float32{170, -170}, 180},
+		{[]float32{-10, -20}, -15},
+		{[]float32{90, 90, 180}, 116.565},
+	}
+	for _, tt := range tests {
+		items := make([]record, len(tt.yaw))
+		for i := range items {
+			items[i].ToW, items[i].Yaw = float64(i)*0.1, tt.yaw[i]
+		}
+		out, err := Series(items, at, Options{Bucket: 10, Agg: "mean"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 180 and -180 are the same direction
+		diff := math.Mod(math.Abs(float64(out[0].Yaw-tt.want)), 360)
+		if diff > 1e-3 && 360-diff > 1e-3 {
+			t.Errorf("mean of %v: %g, want %g", tt.yaw, out[0].Yaw, tt.want)
+		}
+	}
+}
+
+func TestBucketNested(t *testing.T) {
+	type epoch struct {
+		ToW        float64  `json:"tow"`
+		Satellites []record `json:"satellites"`
+	}
+	items := []epoch{{ToW: 0}, {ToW: 1}}
+	_, err := Series(items, func(e *epoch) float64 { return e.ToW }, Options{Bucket: 1, Agg: "mean"})
+	if err == nil || !strings.Contains(err.Error(), "satellites") {
+		t.Errorf("error %v, want the nested satellites rejected", err)
+	}
+}
+
+func TestLttb(t *testing.T) {
+	items := make([]record, 1000)
+	for i := range items {
+		items[i].ToW = float64(i)
+		items[i].CNo = float32(40 + 5*math.Sin(float64(i)/50))
+	}
+	items[500].CNo = 80 // a spike the shape keeps
+
+	for _, n := range []int{3, 10, 100} {
+		out, err := Series(items, at, Options{Points: n, By: "cno"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out) != n {
+			t.Errorf("points=%d: got %d records", n, len(out))
+		}
+		if out[0].ToW != 0 || out[len(out)-1].ToW != 999 {
+			t.Errorf("points=%d: endpoints %g and %g, want 0 and 999", n, out[0].ToW, out[len(out)-1].ToW)
+		}
+		for i := 1; i < len(out); i++ {
+			if out[i].ToW <= out[i-1].ToW {
+				t.Errorf("points=%d: records out of order at %d", n, i)
+			}
+		}
+		if n >= 10 && !containsToW(out, 500) {
+			t.Errorf("points=%d: the spike at tow 500 was dropped", n)
+		}
+	}
+
+	// fewer records than points are returned as they are
+	out, _ := Series(items[:5], at, Options{Points: 10, By: "cno"})
+	if len(out) != 5 {
+		t.Errorf("got %d records of 5, want all of them", len(out))
+	}
+
+	if _, err := Series(items, at, Options{Points: 10, By: "unknown"}); err == nil {
+		t.Error("unknown field accepted")
+	}
+}
+
+func TestGrouped(t *testing.T) {
+	// two prns interleaved in time
+	var items []record
+	for i := 0; i < 12; i++ {
+		items = append(items, record{ToW: float64(i), PRN: uint8(i % 2), CNo: float32(i)})
+	}
+	out, err := Grouped(items, func(r *record) uint8 { return r.PRN }, at, Options{Every: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every other record of each prn (prn 0 at 0, 4, 8 and prn 1 at 1, 5, 9), ordered by time
+	want := []float64{0, 1, 4, 5, 8, 9}
+	if len(out) != len(want) {
+		t.Fatalf("got %d records, want %d", len(out), len(want))
+	}
+	for i := range want {
+		if out[i].ToW != want[i] || out[i].PRN != uint8(int(want[i])%2) {
+			t.Errorf("record %d: tow %g prn %d, want tow %g", i, out[i].ToW, out[i].PRN, want[i])
+		}
+	}
+
+	// without an option the records are not touched
+	if same, _ := Grouped(items, func(r *record) uint8 { return r.PRN }, at, Options{}); len(same) != len(items) {
+		t.Errorf("got %d records without downsampling, want %d", len(same), len(items))
+	}
+}
+
+func containsToW(items []record, tow float64) bool {
+	for i := range items {
+		if items[i].ToW == tow {
+			return true
+		}
+	}
+	return false
+}
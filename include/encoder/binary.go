@@ -2,7 +2,11 @@ package encoder
 
 import (
 	"encoding/binary"
+	"errors"
+	"io"
+	"math"
 	"net/http"
+	"reflect"
 )
 
 // big-endian is standard in networking
@@ -16,3 +20,148 @@ func WriteBinary(w http.ResponseWriter, status int, data any) error {
 func ReadBinary(r *http.Request, data any) error {
 	return binary.Read(r.Body, binary.BigEndian, data)
 }
+
+// --- codec ---
+
+// Records with variable length content (EX: slices) encode themselves
+type BinaryWriter interface {
+	WriteBinary(w io.Writer) error
+}
+
+type BinaryReader interface {
+	ReadBinary(r io.Reader) error
+}
+
+type binaryCodec struct{}
+
+// Media type of records holding slices that do not encode themselves (EX: a projected telemetry epoch), their slices
+// are framed by element counts instead of a stored length
+const CountedBinary = "application/vnd.sturdr.counted"
+
+func (binaryCodec) ContentType() string { return "application/octet-stream" }
+
+// Counted layout for framed records, the plain type otherwise
+func (c binaryCodec) MediaType(data any) string {
+	if _, ok := data.(BinaryWriter); ok {
+		return c.ContentType()
+	}
+	t := reflect.Indirect(reflect.ValueOf(data)).Type()
+	if t.Kind() == reflect.Slice {
+		if reflect.PointerTo(t.Elem()).Implements(reflect.TypeFor[BinaryWriter]()) {
+			return c.ContentType()
+		}
+		t = t.Elem()
+	}
+	if hasSlice(t) {
+		return CountedBinary
+	}
+	return c.ContentType()
+}
+
+// Whether a struct (or its nested structs) has a slice field
+func hasSlice(t reflect.Type) bool {
+	for i := 0; t.Kind() == reflect.Struct && i < t.NumField(); i++ {
+		switch f := t.Field(i).Type; f.Kind() {
+		case reflect.Slice:
+			return true
+		case reflect.Struct:
+			if hasSlice(f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (binaryCodec) Delimited() bool { return true } // fixed size or self-framing records
+
+func (binaryCodec) Encode(w io.Writer, data any) error {
+	if bw, ok := data.(BinaryWriter); ok {
+		return bw.WriteBinary(w)
+	}
+
+	// slices of self-encoding records are written one after another
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice && reflect.PointerTo(v.Type().Elem()).Implements(reflect.TypeFor[BinaryWriter]()) {
+		for i := 0; i < v.Len(); i++ {
+			if err := v.Index(i).Addr().Interface().(BinaryWriter).WriteBinary(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if binary.Size(data) < 0 {
+		return writeFramed(w, reflect.Indirect(v), true)
+	}
+	return binary.Write(w, binary.BigEndian, data)
+}
+
+// Records holding slices (EX: a projected telemetry epoch, sent as CountedBinary) are written field by field, every
+// nested slice after a big-endian uint16 element count (a top level slice is written as records one after another), and optional values
+// as their value (NaN for a nil float, zero otherwise)
+func writeFramed(w io.Writer, v reflect.Value, top bool) error {
+	switch {
+	case v.Kind() == reflect.Pointer:
+		if !v.IsNil() {
+			return writeFramed(w, v.Elem(), false)
+		}
+		nil_value := reflect.New(v.Type().Elem()).Elem()
+		if k := nil_value.Kind(); k == reflect.Float32 || k == reflect.Float64 {
+			nil_value.SetFloat(math.NaN())
+		}
+		return binary.Write(w, binary.BigEndian, nil_value.Interface())
+	case v.Kind() == reflect.Slice:
+		if !top {
+			if err := binary.Write(w, binary.BigEndian, uint16(v.Len())); err != nil {
+				return err
+			}
+		}
+		if binary.Size(v.Interface()) >= 0 {
+			return binary.Write(w, binary.BigEndian, v.Interface())
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := writeFramed(w, v.Index(i), false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case binary.Size(v.Interface()) >= 0:
+		return binary.Write(w, binary.BigEndian, v.Interface())
+	case v.Kind() == reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if err := writeFramed(w, v.Field(i), false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return binary.Write(w, binary.BigEndian, v.Interface())
+}
+
+func (binaryCodec) Decode(r io.Reader, data any) error {
+	if br, ok := data.(BinaryReader); ok {
+		return br.ReadBinary(r)
+	}
+
+	// slices are filled with records until the end of the body
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Slice {
+		s := v.Elem()
+		for {
+			item := reflect.New(s.Type().Elem())
+			var err error
+			if br, ok := item.Interface().(BinaryReader); ok {
+				err = br.ReadBinary(r)
+			} else {
+				err = binary.Read(r, binary.BigEndian, item.Interface())
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			s.Set(reflect.Append(s, item.Elem()))
+		}
+	}
+	return binary.Read(r, binary.BigEndian, data)
+}
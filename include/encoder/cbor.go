@@ -0,0 +1,27 @@
+package encoder
+
+import (
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// CBOR keyed by the json field names (concatenated values form an RFC 8742 sequence)
+type cborCodec struct{}
+
+var (
+	cborEnc, _ = cbor.EncOptions{}.EncMode()
+	cborDec, _ = cbor.DecOptions{ExtraReturnErrors: cbor.ExtraDecErrorUnknownField}.DecMode()
+)
+
+func (cborCodec) ContentType() string { return "application/cbor" }
+
+func (cborCodec) Delimited() bool { return true }
+
+func (cborCodec) Encode(w io.Writer, data any) error {
+	return cborEnc.NewEncoder(w).Encode(data)
+}
+
+func (cborCodec) Decode(r io.Reader, data any) error {
+	return cborDec.NewDecoder(r).Decode(data)
+}
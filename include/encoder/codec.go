@@ -0,0 +1,236 @@
+package encoder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Body format of a mime type, registered once and used by every resource
+type Codec interface {
+	ContentType() string
+	Encode(w io.Writer, data any) error
+	Decode(r io.Reader, data any) error
+}
+
+var (
+	ErrNotAcceptable    = errors.New("none of the accepted media types can be produced")
+	ErrUnsupportedMedia = errors.New("unsupported media type")
+)
+
+// codecs by mime type, and mime types by "format" query alias
+var (
+	mu      sync.RWMutex
+	codecs  = map[string]Codec{}
+	aliases = map[string]string{}
+	order   []string // registration order, the first codec is the default
+)
+
+func init() {
+	Register(jsonCodec{}, "json")
+	Register(binaryCodec{}, "binary")
+	Register(csvCodec{}, "csv")
+	Register(msgpackCodec{}, "msgpack")
+	Register(cborCodec{}, "cbor")
+}
+
+// Add a codec, optionally reachable with "?format=<alias>" (EX: Register(jsonCodec{}, "json"))
+func Register(c Codec, alias ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	mt := c.ContentType()
+	if _, ok := codecs[mt]; !ok {
+		order = append(order, mt)
+	}
+	codecs[mt] = c
+	for _, a := range alias {
+		aliases[a] = mt
+	}
+}
+
+// Registered mime types in registration order
+func Types() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]string(nil), order...)
+}
+
+// Codec of a "format" alias or mime type
+func Lookup(name string) (Codec, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if mt, ok := aliases[name]; ok {
+		name = mt
+	}
+	c, ok := codecs[name]
+	return c, ok
+}
+
+// Codec of a request body ("?format=" first, then "Content-Type", json when neither is given)
+func RequestCodec(r *http.Request) (Codec, error) {
+	if f := r.URL.Query().Get("format"); f != "" {
+		if c, ok := Lookup(f); ok {
+			return c, nil
+		}
+		return nil, fmt.Errorf("%w: format '%s'", ErrUnsupportedMedia, f)
+	}
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return defaultCodec(), nil
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMedia, err.Error())
+	}
+	if c, ok := Lookup(mt); ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("%w '%s' (supported: %s)", ErrUnsupportedMedia, mt, strings.Join(Types(), ", "))
+}
+
+// Codec of a response ("?format=" first, then the best "Accept" match, json when neither is given)
+func ResponseCodec(r *http.Request) (Codec, error) {
+	if f := r.URL.Query().Get("format"); f != "" {
+		if c, ok := Lookup(f); ok {
+			return c, nil
+		}
+		return nil, fmt.Errorf("%w: format '%s'", ErrNotAcceptable, f)
+	}
+	accept := r.Header.Values("Accept")
+	if len(accept) == 0 {
+		return defaultCodec(), nil
+	}
+	for _, mt := range parseAccept(strings.Join(accept, ",")) {
+		if c := match(mt); c != nil {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w (supported: %s)", ErrNotAcceptable, strings.Join(Types(), ", "))
+}
+
+// Decode a request body with its codec
+func Read(r *http.Request, data any) error {
+	c, err := RequestCodec(r)
+	if err != nil {
+		return err
+	}
+	return c.Decode(r.Body, data)
+}
+
+// Encode a response with the negotiated codec (nothing is written when encoding fails, so the caller can still send an error)
+func Write(w http.ResponseWriter, r *http.Request, status int, data any) error {
+	c, err := ResponseCodec(r)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, data); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", MediaType(c, data))
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	Vary(w.Header(), "Accept")
+	w.WriteHeader(status)
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// Add a request header to "Vary" unless it is already listed
+func Vary(h http.Header, field string) {
+	for _, v := range h.Values("Vary") {
+		for _, f := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(f), field) {
+				return
+			}
+		}
+	}
+	h.Add("Vary", field)
+}
+
+// Whether encoded values can simply be concatenated into a stream (codecs report it with a "Delimited() bool" method)
+func Delimited(c Codec) bool {
+	d, ok := c.(interface{ Delimited() bool })
+	return ok && d.Delimited()
+}
+
+// Media type of an encoded value (codecs whose layout depends on the value report it with a "MediaType(any) string"
+// method, EX: counted binary for projected telemetry)
+func MediaType(c Codec, data any) string {
+	if m, ok := c.(interface{ MediaType(any) string }); ok {
+		return m.MediaType(data)
+	}
+	return c.ContentType()
+}
+
+// Http status of a negotiation error (or the fallback for any other error)
+func Status(err error, fallback int) int {
+	switch {
+	case errors.Is(err, ErrNotAcceptable):
+		return http.StatusNotAcceptable
+	case errors.Is(err, ErrUnsupportedMedia):
+		return http.StatusUnsupportedMediaType
+	}
+	return fallback
+}
+
+func defaultCodec() Codec {
+	mu.RLock()
+	defer mu.RUnlock()
+	return codecs[order[0]]
+}
+
+// Best registered codec for an accepted media range ("type/subtype", "type/*" or "*/*")
+func match(mt string) Codec {
+	if c, ok := Lookup(mt); ok {
+		return c
+	}
+	main, sub, ok := strings.Cut(mt, "/")
+	if !ok || sub != "*" {
+		return nil
+	}
+	for _, t := range Types() {
+		if main == "*" || strings.HasPrefix(t, main+"/") {
+			c, _ := Lookup(t)
+			return c
+		}
+	}
+	return nil
+}
+
+// Media ranges of an "Accept" header, ordered by quality (ranges with q=0 are dropped)
+func parseAccept(header string) []string {
+	type entry struct {
+		mt string
+		q  float64
+	}
+	var entries []entry
+	for _, part := range strings.Split(header, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if v, err := strconv.ParseFloat(s, 64); err == nil {
+				q = v
+			}
+		}
+		if q > 0 {
+			entries = append(entries, entry{mt, q})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+
+	types := make([]string, len(entries))
+	for i, e := range entries {
+		types[i] = e.mt
+	}
+	return types
+}
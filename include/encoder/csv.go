@@ -0,0 +1,283 @@
+package encoder
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Comma separated records with a header row of json field names, nested structs are flattened ("navigation.week")
+// and a single slice of structs is expanded into one row per element (EX: one row per satellite of a telemetry epoch)
+type csvCodec struct{}
+
+func (csvCodec) ContentType() string { return "text/csv" }
+
+// Column of a flattened struct
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// Flattened layout of a record type
+type csvLayout struct {
+	cols       []csvColumn
+	slice      []int       // field index of the expanded slice (nil if none)
+	slice_cols []csvColumn // columns of its elements
+}
+
+func (csvCodec) Encode(w io.Writer, data any) error {
+	// 1. records (a single struct or a slice of structs)
+	v := reflect.Indirect(reflect.ValueOf(data))
+	var records []reflect.Value
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			records = append(records, reflect.Indirect(v.Index(i)))
+		}
+	case reflect.Struct:
+		records = append(records, v)
+	default:
+		return fmt.Errorf("%w: csv cannot represent %s", ErrNotAcceptable, v.Type())
+	}
+	t := v.Type()
+	if v.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	layout, err := csvLayoutOf(t)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNotAcceptable, err.Error())
+	}
+
+	// 2. header
+	cw := csv.NewWriter(w)
+	header := make([]string, 0, len(layout.cols)+len(layout.slice_cols))
+	for _, c := range layout.cols {
+		header = append(header, c.name)
+	}
+	for _, c := range layout.slice_cols {
+		header = append(header, c.name)
+	}
+	cw.Write(header)
+
+	// 3. rows
+	row := make([]string, len(header))
+	for _, rec := range records {
+		for i, c := range layout.cols {
+			row[i] = formatValue(rec.FieldByIndex(c.index))
+		}
+		n := len(layout.cols)
+		if layout.slice == nil {
+			cw.Write(row)
+			continue
+		}
+		s := rec.FieldByIndex(layout.slice)
+		if s.Len() == 0 {
+			clear(row[n:])
+			cw.Write(row)
+		}
+		for j := 0; j < s.Len(); j++ {
+			for i, c := range layout.slice_cols {
+				row[n+i] = formatValue(s.Index(j).FieldByIndex(c.index))
+			}
+			cw.Write(row)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (csvCodec) Decode(r io.Reader, data any) error {
+	// 1. target (a struct or a slice of structs)
+	target := reflect.ValueOf(data)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return errors.New("csv: decode target must be a non-nil pointer")
+	}
+	target = target.Elem()
+	t := target.Type()
+	if target.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: csv cannot represent %s", ErrUnsupportedMedia, t)
+	}
+	layout, err := csvLayoutOf(t)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMedia, err.Error())
+	}
+	if layout.slice != nil {
+		return fmt.Errorf("%w: csv cannot represent the nested records of %s", ErrUnsupportedMedia, t)
+	}
+	by_name := make(map[string]csvColumn, len(layout.cols))
+	for _, c := range layout.cols {
+		by_name[c.name] = c
+	}
+
+	// 2. header
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return err
+	}
+	cols := make([]csvColumn, len(header))
+	for i, name := range header {
+		c, ok := by_name[name]
+		if !ok {
+			return fmt.Errorf("csv: unknown column '%s'", name)
+		}
+		cols[i] = c
+	}
+
+	// 3. rows
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return err
+	}
+	if target.Kind() == reflect.Struct && len(rows) != 1 {
+		return fmt.Errorf("csv: expected 1 record, got %d", len(rows))
+	}
+	for _, row := range rows {
+		rec := reflect.New(t).Elem()
+		for i, s := range row {
+			if err := parseValue(rec.FieldByIndex(cols[i].index), s); err != nil {
+				return fmt.Errorf("csv: column '%s': %w", cols[i].name, err)
+			}
+		}
+		if target.Kind() == reflect.Struct {
+			target.Set(rec)
+		} else {
+			target.Set(reflect.Append(target, rec))
+		}
+	}
+	return nil
+}
+
+// Flatten the exported fields of a struct type into columns named by their json tags
+func csvLayoutOf(t reflect.Type) (csvLayout, error) {
+	var layout csvLayout
+	err := flattenFields(t, "", nil, &layout, true)
+	return layout, err
+}
+
+func flattenFields(t reflect.Type, prefix string, index []int, layout *csvLayout, top bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := jsonName(f)
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		name = prefix + name
+		path := append(append([]int(nil), index...), i)
+
+		switch {
+		case f.Type.Kind() == reflect.Struct:
+			if err := flattenFields(f.Type, name+".", path, layout, top); err != nil {
+				return err
+			}
+		case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Struct && top && layout.slice == nil:
+			layout.slice = path
+			var elem csvLayout
+			if err := flattenFields(f.Type.Elem(), name+".", nil, &elem, false); err != nil {
+				return err
+			}
+			layout.slice_cols = elem.cols
+		case f.Type.Kind() == reflect.Pointer && scalar(f.Type.Elem()):
+			layout.cols = append(layout.cols, csvColumn{name: name, index: path}) // an empty cell when nil
+		case f.Type.Kind() == reflect.Slice, f.Type.Kind() == reflect.Map,
+			f.Type.Kind() == reflect.Pointer, f.Type.Kind() == reflect.Interface:
+			return fmt.Errorf("csv cannot represent field '%s'", name)
+		default:
+			layout.cols = append(layout.cols, csvColumn{name: name, index: path})
+		}
+	}
+	return nil
+}
+
+// Json name of a struct field (the go name when untagged)
+func jsonName(f reflect.StructField) string {
+	tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if tag == "" {
+		return f.Name
+	}
+	return tag
+}
+
+// Numbers, booleans and strings (a pointer to one is an optional value)
+func scalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Bool, reflect.String:
+		return true
+	}
+	return false
+}
+
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return ""
+		}
+		return formatValue(v.Elem())
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.String:
+		return v.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func parseValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if s == "" {
+			v.SetZero()
+			return nil
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		return parseValue(v.Elem(), s)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.String:
+		v.SetString(s)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
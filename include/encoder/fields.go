@@ -0,0 +1,219 @@
+package encoder
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Field projection of a record type, selected by json names (EX: "tow,doppler,cno", or "navigation.tow,satellites"
+// for nested records). Selected fields keep the order of the record type. A nil *Fields selects every field.
+type Fields struct {
+	typ   reflect.Type // record type
+	proj  reflect.Type // projected record type
+	picks []fieldPick
+}
+
+type fieldPick struct {
+	index int     // field index in the record type
+	name  string  // json name
+	sub   *Fields // selection of a nested struct, or of the elements of a slice of structs (nil for values)
+}
+
+// Parse a comma separated "fields=" list, validated against the json tags of a record (or slice of records)
+func ParseFields(spec string, record any) (*Fields, error) {
+	var names []string
+	for _, n := range strings.Split(spec, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	t := reflect.TypeOf(record)
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s has no fields", t)
+	}
+	return selectFields(t, names, "")
+}
+
+func selectFields(t reflect.Type, names []string, prefix string) (*Fields, error) {
+	// 1. requested names by field ("" = the whole field)
+	wanted := make(map[string][]string)
+	for _, n := range names {
+		head, rest, _ := strings.Cut(n, ".")
+		wanted[head] = append(wanted[head], rest)
+	}
+
+	// 2. picks in record order
+	f := &Fields{typ: t}
+	var proj []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := jsonName(sf)
+		rest, ok := wanted[name]
+		if !sf.IsExported() || name == "-" || !ok {
+			continue
+		}
+		delete(wanted, name)
+
+		pick := fieldPick{index: i, name: name}
+		ft := sf.Type
+		elem := ft
+		if elem.Kind() == reflect.Slice {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Struct {
+			sub := rest
+			if slices.Contains(rest, "") {
+				sub = fieldNames(elem)
+			}
+			var err error
+			if pick.sub, err = selectFields(elem, sub, prefix+name+"."); err != nil {
+				return nil, err
+			}
+			ft = pick.sub.proj
+			if sf.Type.Kind() == reflect.Slice {
+				ft = reflect.SliceOf(ft)
+			}
+		} else if slices.ContainsFunc(rest, func(s string) bool { return s != "" }) {
+			return nil, fmt.Errorf("field '%s%s' has no fields", prefix, name)
+		}
+		f.picks = append(f.picks, pick)
+		proj = append(proj, reflect.StructField{Name: sf.Name, Type: ft, Tag: sf.Tag})
+	}
+
+	// 3. anything left is not a field
+	if len(wanted) > 0 {
+		unknown := make([]string, 0, len(wanted))
+		for n := range wanted {
+			unknown = append(unknown, prefix+n)
+		}
+		slices.Sort(unknown)
+		return nil, fmt.Errorf("unknown field '%s' (valid fields: %s)",
+			strings.Join(unknown, "', '"), strings.Join(validNames(t, prefix), ", "))
+	}
+	f.proj = reflect.StructOf(proj)
+	return f, nil
+}
+
+// Json names of the exported fields of a struct type
+func fieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonName(t.Field(i)); t.Field(i).IsExported() && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Every selectable name of a struct type (nested records also by their own name)
+func validNames(t reflect.Type, prefix string) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := jsonName(sf)
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		names = append(names, prefix+name)
+		elem := sf.Type
+		if elem.Kind() == reflect.Slice {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Struct {
+			names = append(names, validNames(elem, prefix+name+".")...)
+		}
+	}
+	return names
+}
+
+// Json names of the selected values at this level (the sql columns of the record's table)
+func (f *Fields) Columns() []string {
+	var cols []string
+	for _, p := range f.picks {
+		if p.sub == nil {
+			cols = append(cols, p.name)
+		}
+	}
+	return cols
+}
+
+// Selection of a nested record (false when none of its fields are selected, nil when all of them are)
+func (f *Fields) Sub(name string) (*Fields, bool) {
+	if f == nil {
+		return nil, true
+	}
+	for _, p := range f.picks {
+		if p.name == name && p.sub != nil {
+			return p.sub, true
+		}
+	}
+	return nil, false
+}
+
+// Sql columns and scan targets of the selection, plus extra columns the caller needs (EX: the sequence that matches
+// satellites to their epoch). The record's args hold one pointer per struct field, in field order.
+func (f *Fields) Select(args []any, extra ...string) ([]string, []any) {
+	cols := f.Columns()
+	for _, e := range extra {
+		if !slices.Contains(cols, e) {
+			cols = append(cols, e)
+		}
+	}
+	ptrs := make([]any, len(cols))
+	for i, c := range cols {
+		for j := 0; j < f.typ.NumField(); j++ {
+			if jsonName(f.typ.Field(j)) == c {
+				ptrs[i] = args[j]
+				break
+			}
+		}
+	}
+	return cols, ptrs
+}
+
+// Copy the selected fields of a record, or a slice of records, into the projected type (returned as is for nil)
+func (f *Fields) Project(data any) any {
+	if f == nil {
+		return data
+	}
+	return f.project(reflect.Indirect(reflect.ValueOf(data))).Interface()
+}
+
+func (f *Fields) project(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Slice {
+		out := reflect.MakeSlice(reflect.SliceOf(f.proj), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(f.project(reflect.Indirect(v.Index(i))))
+		}
+		return out
+	}
+	out := reflect.New(f.proj).Elem()
+	for i, p := range f.picks {
+		if p.sub != nil {
+			out.Field(i).Set(p.sub.project(v.Field(p.index)))
+		} else {
+			out.Field(i).Set(v.Field(p.index))
+		}
+	}
+	return out
+}
+
+var selectList = regexp.MustCompile(`(?is)\bSELECT\s+(.*?)\s+FROM\s`)
+
+// Replace the column list of the (outer) SELECT of a statement, the sql files name their columns like the json fields
+func SelectColumns(cmd string, columns []string) string {
+	m := selectList.FindStringSubmatchIndex(cmd)
+	if m == nil {
+		return cmd
+	}
+	return cmd[:m[2]] + strings.Join(columns, ", ") + cmd[m[3]:]
+}
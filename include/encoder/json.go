@@ -2,6 +2,7 @@ package encoder
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 )
 
@@ -17,3 +18,21 @@ func ReadJson(r *http.Request, data any) error {
 	err := decoder.Decode(data)
 	return err
 }
+
+// --- codec ---
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string { return "application/json" }
+
+func (jsonCodec) Delimited() bool { return true } // newline delimited
+
+func (jsonCodec) Encode(w io.Writer, data any) error {
+	return json.NewEncoder(w).Encode(data)
+}
+
+func (jsonCodec) Decode(r io.Reader, data any) error {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(data)
+}
@@ -0,0 +1,29 @@
+package encoder
+
+import (
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MessagePack keyed by the json field names (concatenated values form a stream)
+type msgpackCodec struct{}
+
+func (msgpackCodec) ContentType() string { return "application/msgpack" }
+
+func (msgpackCodec) Delimited() bool { return true }
+
+func (msgpackCodec) Encode(w io.Writer, data any) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	enc.UseCompactFloats(true)
+	return enc.Encode(data)
+}
+
+func (msgpackCodec) Decode(r io.Reader, data any) error {
+	dec := msgpack.NewDecoder(r)
+	dec.SetCustomStructTag("json")
+	dec.DisallowUnknownFields(true)
+	return dec.Decode(data)
+}
@@ -0,0 +1,213 @@
+package generator
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/gnss"
+	"github.com/sturdivant20/sturdr-api/include/logging"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// gps time starts 1980-01-06 and is currently 18 leap seconds ahead of utc
+var gpsEpoch = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+const leapSeconds = 18
+
+var logger = logging.For("generator")
+
+type Generator struct {
+	service telemetry.Service
+	opts    Options
+	orbits  []orbit
+	rng     *rand.Rand
+
+	// platform and receiver state
+	lla        [3]float64        // geodetic position [rad, rad, m]
+	heading    float64           // [rad]
+	clk_bias   float64           // receiver clock bias [m]
+	clk_drift  float64           // receiver clock drift [m/s]
+	ambiguity  map[uint8]float64 // carrier phase offset removed at lock of each tracked satellite [cycles]
+	sequence   uint64
+	last_epoch float64
+}
+
+// Create a generator that simulates a moving platform tracking the gps/galileo constellations
+func NewGenerator(s telemetry.Service, opts Options) *Generator {
+	if opts.Rate <= 0 {
+		opts.Rate = 1.0
+	}
+	return &Generator{
+		service:   s,
+		opts:      opts,
+		orbits:    constellation(opts.Gps, opts.Galileo),
+		rng:       rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0)),
+		lla:       [3]float64{opts.Latitude * deg2rad, opts.Longitude * deg2rad, opts.Altitude},
+		heading:   opts.Heading * deg2rad,
+		clk_bias:  3.0e4,
+		clk_drift: 3.0,
+		ambiguity: make(map[uint8]float64),
+		sequence:  opts.Sequence,
+	}
+}
+
+// Insert simulated epochs at the configured rate until the context is cancelled
+func (g *Generator) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / g.opts.Rate))
+	defer ticker.Stop()
+	logger.Info("Telemetry generator started ...", "rate_hz", g.opts.Rate)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Telemetry generator stopped ...")
+			return
+		case now := <-ticker.C:
+			data := g.Epoch(gpsSeconds(now))
+			if err := g.service.CreateTelemetry(ctx, data); err != nil && ctx.Err() == nil {
+				logger.Error("Telemetry generator error!", "error", err)
+			}
+		}
+	}
+}
+
+// Simulate the next epoch at t seconds of gps time
+func (g *Generator) Epoch(t float64) telemetry.Telemetry {
+	// 1. propagate the platform and receiver clock
+	dt := 0.0
+	if g.last_epoch > 0 {
+		dt = t - g.last_epoch
+	}
+	g.last_epoch = t
+	g.sequence++
+	vned := g.propagate(dt)
+
+	week := math.Floor(t / 604800.0)
+	tow := t - week*604800.0
+	user := geodesy.Lla2Ecef(g.lla)
+	user_vel := geodesy.Ned2EcefVec(vned, g.lla[0], g.lla[1])
+
+	// 2. simulate measurements of every visible satellite
+	var svs []satellite.Satellite
+	var los [][3]float64
+	for i := range g.orbits {
+		o := &g.orbits[i]
+		pos, vel := o.state(t)
+		az, el := geodesy.AzEl(g.lla, pos)
+		if el < g.opts.ElevationMask*deg2rad {
+			delete(g.ambiguity, o.prn)
+			continue
+		}
+		// geometry
+		d := [3]float64{pos[0] - user[0], pos[1] - user[1], pos[2] - user[2]}
+		rng := math.Sqrt(d[0]*d[0] + d[1]*d[1] + d[2]*d[2])
+		u := [3]float64{d[0] / rng, d[1] / rng, d[2] / rng}
+		rng_rate := (vel[0]-user_vel[0])*u[0] + (vel[1]-user_vel[1])*u[1] + (vel[2]-user_vel[2])*u[2]
+		los = append(los, geodesy.Ecef2NedVec(u, g.lla[0], g.lla[1]))
+
+		// atmosphere (code is delayed and carrier is advanced by the ionosphere)
+		sin_el := math.Sin(el)
+		iono := 5.0 / math.Sqrt(1.0-math.Pow(0.94*math.Cos(el), 2))
+		tropo := 2.4 / math.Max(sin_el, 0.05)
+		cno := 30.0 + 18.0*sin_el + 0.5*g.rng.NormFloat64()
+
+		// carrier phase accumulates from zero (plus an integer ambiguity) when the satellite is acquired
+		phase := (rng + g.clk_bias - iono + tropo) / l1Lambda
+		if _, ok := g.ambiguity[o.prn]; !ok {
+			g.ambiguity[o.prn] = math.Round(g.rng.Float64()*100.0) - math.Round(phase)
+		}
+
+		sv := satellite.Satellite{
+			Sequence:  g.sequence,
+			Week:      uint16(week),
+			ToW:       float32(tow),
+			PRN:       o.prn,
+			Health:    0,
+			X:         float32(pos[0]),
+			Y:         float32(pos[1]),
+			Z:         float32(pos[2]),
+			Vx:        float32(vel[0]),
+			Vy:        float32(vel[1]),
+			Vz:        float32(vel[2]),
+			Doppler:   float32(-(rng_rate+g.clk_drift)/l1Lambda + 0.1*g.rng.NormFloat64()),
+			PSR:       float32(rng + g.clk_bias + iono + tropo + (0.5+1.5*(1.0-sin_el))*g.rng.NormFloat64()),
+			ADR:       float32(phase + g.ambiguity[o.prn] + 0.01*g.rng.NormFloat64()),
+			Azimuth:   float32(az * rad2deg),
+			Elevation: float32(el * rad2deg),
+			CNo:       float32(cno),
+		}
+		g.correlators(&sv, cno)
+		svs = append(svs, sv)
+	}
+	sort.Slice(svs, func(i, j int) bool { return svs[i].PRN < svs[j].PRN })
+
+	// 3. navigation solution
+	speed := math.Hypot(vned[0], vned[1])
+	n := navigation.Navigation{
+		Sequence:  g.sequence,
+		Week:      uint16(week),
+		ToW:       float32(tow),
+		NSat:      uint8(len(svs)),
+		Latitude:  float32(g.lla[0] * rad2deg),
+		Longitude: float32(g.lla[1] * rad2deg),
+		Altitude:  float32(g.lla[2]),
+		Vn:        float32(vned[0]),
+		Ve:        float32(vned[1]),
+		Vd:        float32(vned[2]),
+		Roll:      float32(math.Atan(speed*g.opts.TurnRate*deg2rad/9.80665) * rad2deg),
+		Pitch:     0.0,
+		Yaw:       float32(math.Mod(g.heading*rad2deg+360.0, 360.0)),
+	}
+	if d, ok := gnss.Dop(los); ok {
+		n.PDOP, n.HDOP, n.VDOP = float32(d.PDOP), float32(d.HDOP), float32(d.VDOP)
+	}
+
+	return telemetry.Telemetry{Navigation: n, Satellites: svs}
+}
+
+// Move the platform along its (constant turn rate) trajectory, returning its NED velocity
+func (g *Generator) propagate(dt float64) [3]float64 {
+	g.heading += g.opts.TurnRate * deg2rad * dt
+	vned := [3]float64{g.opts.Speed * math.Cos(g.heading), g.opts.Speed * math.Sin(g.heading), 0.0}
+	re, rn := geodesy.Radii(g.lla[0])
+	g.lla[0] += vned[0] * dt / (rn + g.lla[2])
+	g.lla[1] += vned[1] * dt / ((re + g.lla[2]) * math.Cos(g.lla[0]))
+	g.lla[2] -= vned[2] * dt
+
+	// receiver clock is a random walk in drift
+	g.clk_bias += g.clk_drift * dt
+	g.clk_drift += 0.01 * math.Sqrt(dt) * g.rng.NormFloat64()
+	return vned
+}
+
+// Early/prompt/late correlator outputs for a 20 ms coherent integration (unit noise variance)
+func (g *Generator) correlators(sv *satellite.Satellite, cno float64) {
+	const t_int = 0.02
+	const spacing = 0.5
+	amp := math.Sqrt(2.0 * t_int * math.Pow(10.0, cno/10.0))
+	code_err := 0.02 * g.rng.NormFloat64()  // [chips]
+	phase_err := 0.05 * g.rng.NormFloat64() // [rad]
+	triangle := func(tau float64) float64 { return math.Max(1.0-math.Abs(tau), 0.0) }
+
+	e := amp * triangle(code_err+spacing)
+	p := amp * triangle(code_err)
+	l := amp * triangle(code_err-spacing)
+	cos_p, sin_p := math.Cos(phase_err), math.Sin(phase_err)
+	sv.IE = float32(e*cos_p + g.rng.NormFloat64())
+	sv.IP = float32(p*cos_p + g.rng.NormFloat64())
+	sv.IL = float32(l*cos_p + g.rng.NormFloat64())
+	sv.QE = float32(e*sin_p + g.rng.NormFloat64())
+	sv.QP = float32(p*sin_p + g.rng.NormFloat64())
+	sv.QL = float32(l*sin_p + g.rng.NormFloat64())
+}
+
+// Seconds of gps time since the gps epoch
+func gpsSeconds(t time.Time) float64 {
+	return t.Sub(gpsEpoch).Seconds() + leapSeconds
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"math"
+
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+)
+
+const (
+	mu       = 3.986005e14 // earth gravitational parameter [m^3/s^2]
+	gpsA     = 26559700.0  // gps semi-major axis [m]
+	gpsInc   = 55.0        // gps inclination [deg]
+	galA     = 29599800.0  // galileo semi-major axis [m]
+	galInc   = 56.0        // galileo inclination [deg]
+	galPrn0  = 32          // first galileo prn (matches the gui numbering)
+	deg2rad  = geodesy.Deg2Rad
+	rad2deg  = geodesy.Rad2Deg
+	l1Freq   = 1575.42e6 // gps L1 / galileo E1 carrier frequency [Hz]
+	l1Lambda = geodesy.C / l1Freq
+)
+
+// Nominal 24 satellite gps (6 planes) and galileo (3 planes) walker constellations
+func constellation(gps bool, galileo bool) []orbit {
+	var orbits []orbit
+	if gps {
+		for i := 0; i < 24; i++ {
+			plane, slot := i/4, i%4
+			orbits = append(orbits, orbit{
+				prn:   uint8(i),
+				a:     gpsA,
+				inc:   gpsInc * deg2rad,
+				raan0: float64(plane) * 60.0 * deg2rad,
+				u0:    (float64(slot)*90.0 + float64(plane)*15.0) * deg2rad,
+			})
+		}
+	}
+	if galileo {
+		for i := 0; i < 24; i++ {
+			plane, slot := i/8, i%8
+			orbits = append(orbits, orbit{
+				prn:   uint8(galPrn0 + i),
+				a:     galA,
+				inc:   galInc * deg2rad,
+				raan0: float64(plane) * 120.0 * deg2rad,
+				u0:    (float64(slot)*45.0 + float64(plane)*15.0) * deg2rad,
+			})
+		}
+	}
+	return orbits
+}
+
+// ECEF position [m] of the satellite at t seconds of gps time
+func (o *orbit) position(t float64) [3]float64 {
+	n := math.Sqrt(mu / (o.a * o.a * o.a))
+	u := o.u0 + n*t
+	raan := o.raan0 - geodesy.OmegaE*t
+	cos_u, sin_u := math.Cos(u), math.Sin(u)
+	cos_o, sin_o := math.Cos(raan), math.Sin(raan)
+	cos_i, sin_i := math.Cos(o.inc), math.Sin(o.inc)
+	return [3]float64{
+		o.a * (cos_u*cos_o - sin_u*cos_i*sin_o),
+		o.a * (cos_u*sin_o + sin_u*cos_i*cos_o),
+		o.a * sin_u * sin_i,
+	}
+}
+
+// ECEF position [m] and velocity [m/s] of the satellite at t seconds of gps time
+func (o *orbit) state(t float64) ([3]float64, [3]float64) {
+	const h = 0.5
+	p0, p1 := o.position(t-h), o.position(t+h)
+	v := [3]float64{(p1[0] - p0[0]) / (2 * h), (p1[1] - p0[1]) / (2 * h), (p1[2] - p0[2]) / (2 * h)}
+	return o.position(t), v
+}
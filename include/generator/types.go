@@ -0,0 +1,25 @@
+package generator
+
+// Generator options
+type Options struct {
+	Rate          float64 // epochs per second
+	Latitude      float64 // start latitude [deg]
+	Longitude     float64 // start longitude [deg]
+	Altitude      float64 // start altitude [m]
+	Speed         float64 // platform ground speed [m/s]
+	Heading       float64 // start heading [deg]
+	TurnRate      float64 // platform turn rate [deg/s]
+	ElevationMask float64 // minimum elevation of tracked satellites [deg]
+	Gps           bool    // simulate the GPS constellation?
+	Galileo       bool    // simulate the Galileo constellation?
+	Sequence      uint64  // highest sequence already stored (generated epochs continue after it)
+}
+
+// Circular orbit of a simulated satellite
+type orbit struct {
+	prn   uint8
+	a     float64 // semi-major axis [m]
+	inc   float64 // inclination [rad]
+	raan0 float64 // longitude of the ascending node at t = 0 [rad]
+	u0    float64 // argument of latitude at t = 0 [rad]
+}
@@ -0,0 +1,228 @@
+package geodesy
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WGS84 constants
+const (
+	A      = 6378137.0           // semi-major axis [m]
+	F      = 1.0 / 298.257223563 // flattening
+	B      = A * (1.0 - F)       // semi-minor axis [m]
+	E2     = F * (2.0 - F)       // first eccentricity squared
+	OmegaE = 7.2921151467e-5     // earth rotation rate [rad/s]
+	C      = 299792458.0         // speed of light [m/s]
+)
+
+// Angle conversions
+const (
+	Deg2Rad = math.Pi / 180.0
+	Rad2Deg = 180.0 / math.Pi
+)
+
+// Transverse (prime vertical) and meridian radii of curvature at a latitude [rad]
+func Radii(lat float64) (float64, float64) {
+	sin_lat := math.Sin(lat)
+	t := 1.0 - E2*sin_lat*sin_lat
+	re := A / math.Sqrt(t)
+	rn := A * (1.0 - E2) / (t * math.Sqrt(t))
+	return re, rn
+}
+
+// Geodetic latitude [rad], longitude [rad], and altitude [m] to ECEF position [m]
+func Lla2Ecef(lla [3]float64) [3]float64 {
+	re, _ := Radii(lla[0])
+	cos_lat, sin_lat := math.Cos(lla[0]), math.Sin(lla[0])
+	cos_lon, sin_lon := math.Cos(lla[1]), math.Sin(lla[1])
+	return [3]float64{
+		(re + lla[2]) * cos_lat * cos_lon,
+		(re + lla[2]) * cos_lat * sin_lon,
+		(re*(1.0-E2) + lla[2]) * sin_lat,
+	}
+}
+
+// ECEF position [m] to geodetic latitude [rad], longitude [rad], and altitude [m] (Bowring's method, sub-millimeter
+// near the earth but off by decimeters at gps orbit altitude)
+func Ecef2Lla(xyz [3]float64) [3]float64 {
+	x, y, z := xyz[0], xyz[1], xyz[2]
+	ep2 := (A*A - B*B) / (B * B)
+	p := math.Hypot(x, y)
+	if p < 1e-9 {
+		// on the polar axis
+		lat := math.Copysign(math.Pi/2.0, z)
+		return [3]float64{lat, 0.0, math.Abs(z) - B}
+	}
+
+	theta := math.Atan2(z*A, p*B)
+	sin_t, cos_t := math.Sin(theta), math.Cos(theta)
+	lat := math.Atan2(z+ep2*B*sin_t*sin_t*sin_t, p-E2*A*cos_t*cos_t*cos_t)
+	lon := math.Atan2(y, x)
+	re, _ := Radii(lat)
+	alt := p/math.Cos(lat) - re
+	return [3]float64{lat, lon, alt}
+}
+
+// Rotation from the ECEF frame to the local North-East-Down frame at a latitude/longitude [rad]
+func Ecef2NedDcm(lat float64, lon float64) [3][3]float64 {
+	cos_lat, sin_lat := math.Cos(lat), math.Sin(lat)
+	cos_lon, sin_lon := math.Cos(lon), math.Sin(lon)
+	return [3][3]float64{
+		{-sin_lat * cos_lon, -sin_lat * sin_lon, cos_lat},
+		{-sin_lon, cos_lon, 0.0},
+		{-cos_lat * cos_lon, -cos_lat * sin_lon, -sin_lat},
+	}
+}
+
+// Rotate an ECEF vector into the NED frame at a latitude/longitude [rad]
+func Ecef2NedVec(v [3]float64, lat float64, lon float64) [3]float64 {
+	return mulMat(Ecef2NedDcm(lat, lon), v)
+}
+
+// Rotate a NED vector into the ECEF frame at a latitude/longitude [rad]
+func Ned2EcefVec(v [3]float64, lat float64, lon float64) [3]float64 {
+	return mulMatT(Ecef2NedDcm(lat, lon), v)
+}
+
+// Rotation from the ECEF frame to the local East-North-Up frame at a latitude/longitude [rad]
+func Ecef2EnuDcm(lat float64, lon float64) [3][3]float64 {
+	ned := Ecef2NedDcm(lat, lon)
+	return [3][3]float64{ned[1], ned[0], {-ned[2][0], -ned[2][1], -ned[2][2]}}
+}
+
+// Rotate an ECEF vector into the ENU frame at a latitude/longitude [rad]
+func Ecef2EnuVec(v [3]float64, lat float64, lon float64) [3]float64 {
+	return mulMat(Ecef2EnuDcm(lat, lon), v)
+}
+
+// Rotate an ENU vector into the ECEF frame at a latitude/longitude [rad]
+func Enu2EcefVec(v [3]float64, lat float64, lon float64) [3]float64 {
+	return mulMatT(Ecef2EnuDcm(lat, lon), v)
+}
+
+// Swap a local level vector between NED and ENU (the conversion is its own inverse)
+func Ned2Enu(v [3]float64) [3]float64 {
+	return [3]float64{v[1], v[0], -v[2]}
+}
+
+// --- local level frame ---
+
+// Origin of a local level frame (EX: a surveyed antenna position)
+type Origin struct {
+	lla  [3]float64 // [rad, rad, m]
+	ecef [3]float64 // [m]
+	dcm  [3][3]float64
+}
+
+// Local level frame at a geodetic position [rad, rad, m]
+func NewOrigin(lla [3]float64) Origin {
+	return Origin{lla: lla, ecef: Lla2Ecef(lla), dcm: Ecef2EnuDcm(lla[0], lla[1])}
+}
+
+// Local level frame at an ECEF position [m]
+func NewOriginEcef(xyz [3]float64) Origin {
+	lla := Ecef2Lla(xyz)
+	return Origin{lla: lla, ecef: xyz, dcm: Ecef2EnuDcm(lla[0], lla[1])}
+}
+
+// Geodetic position of the origin [rad, rad, m]
+func (o Origin) Lla() [3]float64 {
+	return o.lla
+}
+
+// ECEF position of the origin [m]
+func (o Origin) Ecef() [3]float64 {
+	return o.ecef
+}
+
+// ECEF position [m] to East-North-Up relative to the origin [m]
+func (o Origin) Ecef2Enu(xyz [3]float64) [3]float64 {
+	return mulMat(o.dcm, [3]float64{xyz[0] - o.ecef[0], xyz[1] - o.ecef[1], xyz[2] - o.ecef[2]})
+}
+
+// East-North-Up relative to the origin [m] to ECEF position [m]
+func (o Origin) Enu2Ecef(enu [3]float64) [3]float64 {
+	d := mulMatT(o.dcm, enu)
+	return [3]float64{o.ecef[0] + d[0], o.ecef[1] + d[1], o.ecef[2] + d[2]}
+}
+
+// Geodetic position [rad, rad, m] to East-North-Up relative to the origin [m]
+func (o Origin) Lla2Enu(lla [3]float64) [3]float64 {
+	return o.Ecef2Enu(Lla2Ecef(lla))
+}
+
+// East-North-Up relative to the origin [m] to geodetic position [rad, rad, m]
+func (o Origin) Enu2Lla(enu [3]float64) [3]float64 {
+	return Ecef2Lla(o.Enu2Ecef(enu))
+}
+
+// Geodetic position [rad, rad, m] to North-East-Down relative to the origin [m]
+func (o Origin) Lla2Ned(lla [3]float64) [3]float64 {
+	return Ned2Enu(o.Lla2Enu(lla))
+}
+
+// Rotate a vector from the NED frame at a geodetic position [rad] into the ENU frame of the origin (EX: a velocity)
+func (o Origin) Ned2EnuVec(v [3]float64, lat float64, lon float64) [3]float64 {
+	return mulMat(o.dcm, Ned2EcefVec(v, lat, lon))
+}
+
+// --- parsing ---
+
+// Three comma separated finite numbers (EX: "32.5864,-85.4944,200")
+func ParsePoint(s string) ([3]float64, error) {
+	var v [3]float64
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("expected 3 comma separated values, got '%s'", s)
+	}
+	for i, p := range parts {
+		x, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil || math.IsNaN(x) || math.IsInf(x, 0) {
+			return v, fmt.Errorf("invalid number '%s'", p)
+		}
+		v[i] = x
+	}
+	return v, nil
+}
+
+// Origin from "latitude,longitude,altitude" in degrees and meters
+func ParseOrigin(s string) (Origin, error) {
+	v, err := ParsePoint(s)
+	if err != nil || math.Abs(v[0]) > 90 || math.Abs(v[1]) > 180 {
+		return Origin{}, fmt.Errorf("origin must be 'latitude,longitude,altitude' in degrees and meters, got '%s'", s)
+	}
+	return NewOrigin([3]float64{v[0] * Deg2Rad, v[1] * Deg2Rad, v[2]}), nil
+}
+
+// Azimuth [rad] and elevation [rad] of a target ECEF position [m] seen from a user geodetic position
+func AzEl(user_lla [3]float64, target [3]float64) (float64, float64) {
+	user := Lla2Ecef(user_lla)
+	d := [3]float64{target[0] - user[0], target[1] - user[1], target[2] - user[2]}
+	ned := Ecef2NedVec(d, user_lla[0], user_lla[1])
+	az := math.Atan2(ned[1], ned[0])
+	if az < 0.0 {
+		az += 2.0 * math.Pi
+	}
+	el := math.Atan2(-ned[2], math.Hypot(ned[0], ned[1]))
+	return az, el
+}
+
+// matrix-vector product
+func mulMat(m [3][3]float64, v [3]float64) [3]float64 {
+	return [3]float64{
+		m[0][0]*v[0] + m[0][1]*v[1] + m[0][2]*v[2],
+		m[1][0]*v[0] + m[1][1]*v[1] + m[1][2]*v[2],
+		m[2][0]*v[0] + m[2][1]*v[1] + m[2][2]*v[2],
+	}
+}
+
+// transposed matrix-vector product
+func mulMatT(m [3][3]float64, v [3]float64) [3]float64 {
+	return [3]float64{
+		m[0][0]*v[0] + m[1][0]*v[1] + m[2][0]*v[2],
+		m[0][1]*v[0] + m[1][1]*v[1] + m[2][1]*v[2],
+		m[0][2]*v[0] + m[1][2]*v[1] + m[2][2]*v[2],
+	}
+}
@@ -0,0 +1,194 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+)
+
+func near3(a, b [3]float64, tol float64) bool {
+	return math.Abs(a[0]-b[0]) <= tol && math.Abs(a[1]-b[1]) <= tol && math.Abs(a[2]-b[2]) <= tol
+}
+
+func deg(lat, lon, alt float64) [3]float64 {
+	return [3]float64{lat * Deg2Rad, lon * Deg2Rad, alt}
+}
+
+func TestLla2Ecef(t *testing.T) {
+	tests := []struct {
+		name string
+		lla  [3]float64
+		want [3]float64
+	}{
+		{"equator, prime meridian", deg(0, 0, 0), [3]float64{A, 0, 0}},
+		{"equator, 90 east, 1 km up", deg(0, 90, 1000), [3]float64{0, A + 1000, 0}},
+		{"north pole", deg(90, 0, 0), [3]float64{0, 0, B}},
+		{"south pole, 100 m down", deg(-90, 0, -100), [3]float64{0, 0, -B + 100}},
+		{"45 north, 45 east", deg(45, 45, 0), [3]float64{3194419.1451, 3194419.1451, 4487348.4089}},
+		{"auburn", deg(32.5864, -85.4944, 200), [3]float64{422593.7709, -5362861.6145, 3415507.9075}},
+		{"sydney", deg(-33.8688, 151.2093, 58), [3]float64{-4646093.4773, 2553229.5358, -3534404.7109}},
+	}
+	for _, tt := range tests {
+		if got := Lla2Ecef(tt.lla); !near3(got, tt.want, 1e-3) {
+			t.Errorf("%s: Lla2Ecef = %.4f, want %.4f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEcefLlaRoundTrip(t *testing.T) {
+	// from below the ellipsoid to the edge of space (receiver positions), both poles and the antimeridian
+	for _, lat := range []float64{-90, -89.999, -60, -33.8688, 0, 32.5864, 45, 89.5, 90} {
+		for _, lon := range []float64{-180, -85.4944, 0, 45, 151.2093, 179.999} {
+			for _, alt := range []float64{-400, 0, 200, 8848, 1e5} {
+				lla := deg(lat, lon, alt)
+				got := Ecef2Lla(Lla2Ecef(lla))
+
+				// longitude is undefined on the poles, and -180 equals 180
+				if math.Abs(lat) == 90 {
+					got[1], lla[1] = 0, 0
+				}
+				dlon := math.Remainder(got[1]-lla[1], 2*math.Pi)
+				if math.Abs(got[0]-lla[0]) > 1e-10 || math.Abs(dlon) > 1e-10 || math.Abs(got[2]-lla[2]) > 1e-3 {
+					t.Errorf("lla (%g, %g, %g): round trip (%.10f, %.10f, %.4f)", lat, lon, alt,
+						got[0]*Rad2Deg, got[1]*Rad2Deg, got[2])
+				}
+			}
+		}
+	}
+}
+
+func TestOriginRoundTrip(t *testing.T) {
+	o := NewOrigin(deg(32.5864, -85.4944, 200))
+
+	// the origin itself, and the ecef constructor finds the same frame
+	if enu := o.Lla2Enu(o.Lla()); !near3(enu, [3]float64{}, 1e-6) {
+		t.Errorf("origin in its own frame %v, want 0", enu)
+	}
+	if oe := NewOriginEcef(o.Ecef()); !near3(oe.Lla(), o.Lla(), 1e-9) {
+		t.Errorf("NewOriginEcef lla %v, want %v", oe.Lla(), o.Lla())
+	}
+
+	// straight up along the normal, and a small step north and east
+	re, rn := Radii(o.Lla()[0])
+	tests := []struct {
+		name string
+		lla  [3]float64
+		want [3]float64
+		tol  float64
+	}{
+		{"100 m up", deg(32.5864, -85.4944, 300), [3]float64{0, 0, 100}, 1e-6},
+		{"1e-5 deg north", deg(32.5864+1e-5, -85.4944, 200), [3]float64{0, (rn + 200) * 1e-5 * Deg2Rad, 0}, 1e-3},
+		{"1e-5 deg east", deg(32.5864, -85.4944+1e-5, 200),
+			[3]float64{(re + 200) * math.Cos(32.5864*Deg2Rad) * 1e-5 * Deg2Rad, 0, 0}, 1e-3},
+	}
+	for _, tt := range tests {
+		if got := o.Lla2Enu(tt.lla); !near3(got, tt.want, tt.tol) {
+			t.Errorf("%s: enu %.6f, want %.6f", tt.name, got, tt.want)
+		}
+	}
+
+	// enu -> lla -> enu and ned as the swapped enu
+	for _, enu := range [][3]float64{{0, 0, 0}, {1000, -2000, 50}, {-25e3, 40e3, -300}, {3e5, 1e5, 2e4}} {
+		lla := o.Enu2Lla(enu)
+		if got := o.Lla2Enu(lla); !near3(got, enu, 1e-4) {
+			t.Errorf("enu %v: round trip %v", enu, got)
+		}
+		if got := o.Lla2Ned(lla); !near3(got, [3]float64{enu[1], enu[0], -enu[2]}, 1e-4) {
+			t.Errorf("enu %v: ned %v", enu, got)
+		}
+		if got := o.Ecef2Enu(o.Enu2Ecef(enu)); !near3(got, enu, 1e-6) {
+			t.Errorf("enu %v: ecef round trip %v", enu, got)
+		}
+	}
+}
+
+func TestFrameRotations(t *testing.T) {
+	lat, lon := 32.5864*Deg2Rad, -85.4944*Deg2Rad
+
+	// orthonormal rotation, and the local up is the ellipsoid normal
+	dcm := Ecef2NedDcm(lat, lon)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			dot := dcm[i][0]*dcm[j][0] + dcm[i][1]*dcm[j][1] + dcm[i][2]*dcm[j][2]
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(dot-want) > 1e-12 {
+				t.Errorf("rows %d and %d: dot %g, want %g", i, j, dot, want)
+			}
+		}
+	}
+	up := Ecef2EnuVec([3]float64{math.Cos(lat) * math.Cos(lon), math.Cos(lat) * math.Sin(lon), math.Sin(lat)}, lat, lon)
+	if !near3(up, [3]float64{0, 0, 1}, 1e-12) {
+		t.Errorf("normal in enu %v, want up", up)
+	}
+
+	for _, v := range [][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1.5, -0.5, 0.1}} {
+		if got := Ned2EcefVec(Ecef2NedVec(v, lat, lon), lat, lon); !near3(got, v, 1e-12) {
+			t.Errorf("ned round trip of %v: %v", v, got)
+		}
+		if got := Enu2EcefVec(Ecef2EnuVec(v, lat, lon), lat, lon); !near3(got, v, 1e-12) {
+			t.Errorf("enu round trip of %v: %v", v, got)
+		}
+		if got := Ned2Enu(Ecef2NedVec(v, lat, lon)); !near3(got, Ecef2EnuVec(v, lat, lon), 1e-12) {
+			t.Errorf("ned of %v does not swap into enu", v)
+		}
+		if got := Ned2Enu(Ned2Enu(v)); got != v {
+			t.Errorf("Ned2Enu twice %v, want %v", got, v)
+		}
+	}
+
+	// a velocity at the origin keeps its components in the origin's frame
+	o := NewOrigin([3]float64{lat, lon, 200})
+	if got := o.Ned2EnuVec([3]float64{1.5, -0.5, 0.1}, lat, lon); !near3(got, [3]float64{-0.5, 1.5, -0.1}, 1e-12) {
+		t.Errorf("velocity at the origin %v", got)
+	}
+}
+
+func TestAzEl(t *testing.T) {
+	user := deg(32.5864, -85.4944, 200)
+	o := NewOrigin(user)
+	tests := []struct {
+		name   string
+		enu    [3]float64
+		az, el float64 // [deg]
+	}{
+		{"zenith", [3]float64{0, 0, 20e6}, 0, 90},
+		{"north horizon", [3]float64{0, 1000, 0}, 0, 0},
+		{"east 45 up", [3]float64{1000, 0, 1000}, 90, 45},
+		{"south west", [3]float64{-1000, -1000, 0}, 225, 0},
+	}
+	for _, tt := range tests {
+		az, el := AzEl(user, o.Enu2Ecef(tt.enu))
+		if tt.el == 90 {
+			az = 0 // undefined at the zenith
+		}
+		if math.Abs(az*Rad2Deg-tt.az) > 1e-6 || math.Abs(el*Rad2Deg-tt.el) > 1e-6 {
+			t.Errorf("%s: az/el %.6f/%.6f, want %g/%g", tt.name, az*Rad2Deg, el*Rad2Deg, tt.az, tt.el)
+		}
+	}
+}
+
+func TestParseOrigin(t *testing.T) {
+	tests := []struct {
+		s  string
+		ok bool
+	}{
+		{"32.5864,-85.4944,200", true},
+		{" -33.8688 , 151.2093 , 58 ", true},
+		{"91,0,0", false},
+		{"0,181,0", false},
+		{"0,0", false},
+		{"0,0,NaN", false},
+		{"a,b,c", false},
+	}
+	for _, tt := range tests {
+		o, err := ParseOrigin(tt.s)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseOrigin(%q): error %v", tt.s, err)
+		}
+		if tt.ok && o.Ecef() == ([3]float64{}) {
+			t.Errorf("ParseOrigin(%q): origin without a position", tt.s)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package gnss
+
+import "math"
+
+// Dilution of precision of a satellite geometry
+type DOP struct {
+	PDOP float64 `json:"pdop"`
+	HDOP float64 `json:"hdop"`
+	VDOP float64 `json:"vdop"`
+	TDOP float64 `json:"tdop"`
+}
+
+// Dilution of precision from NED line-of-sight unit vectors (false for less than 4 satellites or a singular geometry)
+func Dop(los [][3]float64) (DOP, bool) {
+	if len(los) < 4 {
+		return DOP{}, false
+	}
+
+	// normal matrix of the geometry matrix [-u_n, -u_e, -u_d, 1]
+	var m [4][4]float64
+	for _, u := range los {
+		row := [4]float64{-u[0], -u[1], -u[2], 1.0}
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				m[i][j] += row[i] * row[j]
+			}
+		}
+	}
+	q, ok := Inv4(m)
+	if !ok {
+		return DOP{}, false
+	}
+	return DOP{
+		PDOP: math.Sqrt(q[0][0] + q[1][1] + q[2][2]),
+		HDOP: math.Sqrt(q[0][0] + q[1][1]),
+		VDOP: math.Sqrt(q[2][2]),
+		TDOP: math.Sqrt(q[3][3]),
+	}, true
+}
+
+// Gauss-Jordan inverse of a 4x4 matrix
+func Inv4(m [4][4]float64) ([4][4]float64, bool) {
+	var inv [4][4]float64
+	for i := 0; i < 4; i++ {
+		inv[i][i] = 1.0
+	}
+	for c := 0; c < 4; c++ {
+		// partial pivot
+		p := c
+		for r := c + 1; r < 4; r++ {
+			if math.Abs(m[r][c]) > math.Abs(m[p][c]) {
+				p = r
+			}
+		}
+		if math.Abs(m[p][c]) < 1e-12 {
+			return inv, false
+		}
+		m[c], m[p] = m[p], m[c]
+		inv[c], inv[p] = inv[p], inv[c]
+
+		d := m[c][c]
+		for j := 0; j < 4; j++ {
+			m[c][j] /= d
+			inv[c][j] /= d
+		}
+		for r := 0; r < 4; r++ {
+			if r == c {
+				continue
+			}
+			f := m[r][c]
+			for j := 0; j < 4; j++ {
+				m[r][j] -= f * m[c][j]
+				inv[r][j] -= f * inv[c][j]
+			}
+		}
+	}
+	return inv, true
+}
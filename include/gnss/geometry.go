@@ -0,0 +1,139 @@
+package gnss
+
+import (
+	"math"
+
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+// Geometry of a satellite seen from a user position
+type Geometry struct {
+	Range     float64    // [m]
+	Unit      [3]float64 // ECEF unit vector from the user to the satellite
+	LOS       [3]float64 // NED unit vector from the user to the satellite
+	Azimuth   float64    // [rad]
+	Elevation float64    // [rad]
+}
+
+// Geometry of a satellite ECEF position [m] from a user geodetic position [rad, rad, m]
+func SatelliteGeometry(user_lla [3]float64, sat [3]float64) Geometry {
+	user := geodesy.Lla2Ecef(user_lla)
+	d := [3]float64{sat[0] - user[0], sat[1] - user[1], sat[2] - user[2]}
+	rng := math.Sqrt(d[0]*d[0] + d[1]*d[1] + d[2]*d[2])
+	u := [3]float64{d[0] / rng, d[1] / rng, d[2] / rng}
+	los := geodesy.Ecef2NedVec(u, user_lla[0], user_lla[1])
+	az := math.Atan2(los[1], los[0])
+	if az < 0.0 {
+		az += 2.0 * math.Pi
+	}
+	return Geometry{Range: rng, Unit: u, LOS: los, Azimuth: az, Elevation: math.Asin(-los[2])}
+}
+
+// Geodetic position of a navigation solution [rad, rad, m] (false when the receiver has no fix)
+func UserPosition(n *navigation.Navigation) ([3]float64, bool) {
+	lla := [3]float64{float64(n.Latitude) * geodesy.Deg2Rad, float64(n.Longitude) * geodesy.Deg2Rad, float64(n.Altitude)}
+	return lla, lla != [3]float64{}
+}
+
+// Whether a satellite record carries an ECEF position
+func HasPosition(sv *satellite.Satellite) bool {
+	return sv.X != 0 || sv.Y != 0 || sv.Z != 0
+}
+
+// --- receiver check ---
+
+// Tolerances of the recomputed geometry against the receiver values
+type CheckOptions struct {
+	Fill          bool    // fill in azimuth, elevation and dop values the receiver left zero
+	AzElTolerance float64 // [deg]
+	DopTolerance  float64
+}
+
+// Tolerances of a check without settings
+var DefaultCheckOptions = CheckOptions{AzElTolerance: 1.0, DopTolerance: 0.1}
+
+// Receiver reported and recomputed value
+type Value struct {
+	Reported float64 `json:"reported"`
+	Computed float64 `json:"computed"`
+	Mismatch bool    `json:"mismatch"` // a reported (non-zero) value differs from the computed one by more than the tolerance
+	Filled   bool    `json:"filled"`   // a zero reported value was replaced by the computed one
+}
+
+// Recomputed azimuth and elevation of one satellite [deg]
+type SatelliteCheck struct {
+	PRN       uint8 `json:"prn"`
+	Azimuth   Value `json:"azimuth"`
+	Elevation Value `json:"elevation"`
+}
+
+// Recomputed geometry of one epoch
+type Check struct {
+	Sequence   uint64           `json:"sequence"`
+	Week       uint16           `json:"week"`
+	ToW        float32          `json:"tow"`
+	PDOP       Value            `json:"pdop"`
+	HDOP       Value            `json:"hdop"`
+	VDOP       Value            `json:"vdop"`
+	Satellites []SatelliteCheck `json:"satellites"` // satellites with a position
+	Mismatches int              `json:"mismatches"`
+	Filled     int              `json:"filled"`
+}
+
+// Recompute the azimuth/elevation of every satellite with a position and the dop of the satellites above the
+// horizon, comparing them with the receiver values (zero values are filled in place when asked)
+func CheckGeometry(n *navigation.Navigation, svs []satellite.Satellite, opts CheckOptions) Check {
+	c := Check{Sequence: n.Sequence, Week: n.Week, ToW: n.ToW, Satellites: []SatelliteCheck{}}
+	user, ok := UserPosition(n)
+	if !ok {
+		return c
+	}
+
+	var los [][3]float64
+	for i := range svs {
+		sv := &svs[i]
+		if !HasPosition(sv) {
+			continue
+		}
+		g := SatelliteGeometry(user, [3]float64{float64(sv.X), float64(sv.Y), float64(sv.Z)})
+		if g.Elevation > 0 {
+			los = append(los, g.LOS)
+		}
+		sc := SatelliteCheck{PRN: sv.PRN}
+		sc.Azimuth = c.compare(&sv.Azimuth, g.Azimuth*geodesy.Rad2Deg, opts.AzElTolerance, opts.Fill, true)
+		sc.Elevation = c.compare(&sv.Elevation, g.Elevation*geodesy.Rad2Deg, opts.AzElTolerance, opts.Fill, false)
+		c.Satellites = append(c.Satellites, sc)
+	}
+
+	if d, ok := Dop(los); ok {
+		c.PDOP = c.compare(&n.PDOP, d.PDOP, opts.DopTolerance, opts.Fill, false)
+		c.HDOP = c.compare(&n.HDOP, d.HDOP, opts.DopTolerance, opts.Fill, false)
+		c.VDOP = c.compare(&n.VDOP, d.VDOP, opts.DopTolerance, opts.Fill, false)
+	}
+	return c
+}
+
+// Compare a reported value with the computed one (angles compare across the wrap), filling it when zero
+func (c *Check) compare(reported *float32, computed float64, tol float64, fill bool, angle bool) Value {
+	v := Value{Reported: float64(*reported), Computed: computed}
+	switch {
+	case *reported == 0:
+		if fill {
+			*reported = float32(computed)
+			v.Filled = true
+			c.Filled++
+		}
+	default:
+		diff := v.Reported - computed
+		if angle {
+			diff -= 360 * math.Round(diff/360)
+		}
+		if math.Abs(diff) > tol {
+			v.Mismatch = true
+			c.Mismatches++
+		}
+	}
+	return v
+}
@@ -0,0 +1,162 @@
+package gnss
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+// Navigation epoch of the synthetic geometries
+func testNavigation() navigation.Navigation {
+	return navigation.Navigation{Sequence: 1, Week: 2300, ToW: 1000, Latitude: 32.5864, Longitude: -85.4944,
+		Altitude: 200}
+}
+
+// Satellite at an azimuth/elevation [deg] of the navigation position, 20200 km along the line of sight
+func testSatellite(n *navigation.Navigation, prn uint8, az, el float64) satellite.Satellite {
+	user, _ := UserPosition(n)
+	o := geodesy.NewOrigin(user)
+	az, el = az*geodesy.Deg2Rad, el*geodesy.Deg2Rad
+	xyz := o.Enu2Ecef([3]float64{
+		20200e3 * math.Cos(el) * math.Sin(az), 20200e3 * math.Cos(el) * math.Cos(az), 20200e3 * math.Sin(el)})
+	return satellite.Satellite{Sequence: n.Sequence, Week: n.Week, ToW: n.ToW, PRN: prn,
+		X: float32(xyz[0]), Y: float32(xyz[1]), Z: float32(xyz[2])}
+}
+
+// NED line of sight at an azimuth/elevation [deg]
+func los(az, el float64) [3]float64 {
+	az, el = az*geodesy.Deg2Rad, el*geodesy.Deg2Rad
+	return [3]float64{math.Cos(el) * math.Cos(az), math.Cos(el) * math.Sin(az), -math.Sin(el)}
+}
+
+func TestDop(t *testing.T) {
+	tests := []struct {
+		name string
+		los  [][3]float64
+		want DOP
+		ok   bool
+	}{
+		// zenith plus three on the horizon 120 deg apart: diag(2/3, 2/3, 4/3, 1/3) (hand computed)
+		{"zenith and horizon", [][3]float64{los(0, 90), los(0, 0), los(120, 0), los(240, 0)},
+			DOP{PDOP: math.Sqrt(8.0 / 3.0), HDOP: math.Sqrt(4.0 / 3.0), VDOP: math.Sqrt(4.0 / 3.0), TDOP: math.Sqrt(1.0 / 3.0)},
+			true},
+		// zenith plus three at 30 deg elevation: diag(8/9, 8/9, 16/3, 7/3)
+		{"zenith and 30 deg", [][3]float64{los(0, 90), los(0, 30), los(120, 30), los(240, 30)},
+			DOP{PDOP: 8.0 / 3.0, HDOP: 4.0 / 3.0, VDOP: math.Sqrt(16.0 / 3.0), TDOP: math.Sqrt(7.0 / 3.0)}, true},
+		{"three satellites", [][3]float64{los(0, 90), los(0, 30), los(120, 30)}, DOP{}, false},
+		{"one direction", [][3]float64{los(10, 45), los(10, 45), los(10, 45), los(10, 45)}, DOP{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := Dop(tt.los)
+		if ok != tt.ok {
+			t.Errorf("%s: ok %v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		if math.Abs(got.PDOP-tt.want.PDOP) > 1e-9 || math.Abs(got.HDOP-tt.want.HDOP) > 1e-9 ||
+			math.Abs(got.VDOP-tt.want.VDOP) > 1e-9 || math.Abs(got.TDOP-tt.want.TDOP) > 1e-9 {
+			t.Errorf("%s: %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInv4(t *testing.T) {
+	m := [4][4]float64{{4, 1, 0, 2}, {1, 3, 1, 0}, {0, 1, 5, 1}, {2, 0, 1, 6}}
+	inv, ok := Inv4(m)
+	if !ok {
+		t.Fatal("regular matrix reported singular")
+	}
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			p := 0.0
+			for k := 0; k < 4; k++ {
+				p += m[i][k] * inv[k][j]
+			}
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(p-want) > 1e-12 {
+				t.Errorf("(m * inv)[%d][%d] = %g, want %g", i, j, p, want)
+			}
+		}
+	}
+	if _, ok := Inv4([4][4]float64{{1, 2, 3, 4}, {2, 4, 6, 8}, {0, 1, 0, 0}, {0, 0, 1, 0}}); ok {
+		t.Error("singular matrix inverted")
+	}
+}
+
+func TestSatelliteGeometry(t *testing.T) {
+	n := testNavigation()
+	user, _ := UserPosition(&n)
+	for _, ae := range [][2]float64{{0, 90}, {45, 30}, {200, 5}, {359, 60}, {90, -10}} {
+		sv := testSatellite(&n, 1, ae[0], ae[1])
+		g := SatelliteGeometry(user, [3]float64{float64(sv.X), float64(sv.Y), float64(sv.Z)})
+		az := g.Azimuth * geodesy.Rad2Deg
+		if ae[1] == 90 {
+			az = 0 // undefined at the zenith
+		}
+		if math.Abs(az-ae[0]) > 1e-4 || math.Abs(g.Elevation*geodesy.Rad2Deg-ae[1]) > 1e-4 || math.Abs(g.Range-20200e3) > 2 {
+			t.Errorf("az/el %v: %.5f/%.5f at %.1f m", ae, az, g.Elevation*geodesy.Rad2Deg, g.Range)
+		}
+		if u := g.LOS; math.Abs(u[0]*u[0]+u[1]*u[1]+u[2]*u[2]-1) > 1e-12 {
+			t.Errorf("az/el %v: line of sight %v is not a unit vector", ae, u)
+		}
+	}
+}
+
+func TestCheckGeometry(t *testing.T) {
+	// zenith plus three at 30 deg, and one below the horizon (checked, but not in the dop)
+	geometry := func() (navigation.Navigation, []satellite.Satellite) {
+		n := testNavigation()
+		return n, []satellite.Satellite{
+			testSatellite(&n, 1, 0, 90), testSatellite(&n, 2, 0, 30), testSatellite(&n, 3, 120, 30),
+			testSatellite(&n, 4, 240, 30), testSatellite(&n, 5, 90, -5),
+		}
+	}
+
+	// 1. receiver values that agree (the azimuth of 0 deg reported as 359.9 deg, none at the zenith), and one wrong
+	// elevation and pdop
+	n, svs := geometry()
+	n.PDOP, n.HDOP, n.VDOP = 8.0/3.0+0.5, 4.0/3.0, float32(math.Sqrt(16.0/3.0))
+	for i, ae := range [][2]float32{{0, 90}, {359.9, 30}, {120, 30}, {240, 25}, {90, -5}} {
+		svs[i].Azimuth, svs[i].Elevation = ae[0], ae[1]
+	}
+	c := CheckGeometry(&n, svs, DefaultCheckOptions)
+	if len(c.Satellites) != 5 || c.Mismatches != 2 || c.Filled != 0 {
+		t.Fatalf("%d satellites, %d mismatches, %d filled, want 5, 2 and 0", len(c.Satellites), c.Mismatches, c.Filled)
+	}
+	if !c.PDOP.Mismatch || c.HDOP.Mismatch || c.VDOP.Mismatch || math.Abs(c.PDOP.Computed-8.0/3.0) > 1e-4 {
+		t.Errorf("dop %+v %+v %+v, want only the pdop mismatched", c.PDOP, c.HDOP, c.VDOP)
+	}
+	if s := c.Satellites[3]; !s.Elevation.Mismatch || s.Azimuth.Mismatch {
+		t.Errorf("prn 4 %+v, want the elevation mismatched", s)
+	}
+	if s := c.Satellites[1]; s.Azimuth.Mismatch {
+		t.Errorf("prn 2 azimuth %+v, 359.9 deg should match 0 deg", s.Azimuth)
+	}
+
+	// 2. zero values are filled in place when asked, and left alone otherwise
+	n, svs = geometry()
+	c = CheckGeometry(&n, svs, CheckOptions{Fill: false, AzElTolerance: 1, DopTolerance: 0.1})
+	if c.Filled != 0 || c.Mismatches != 0 || n.HDOP != 0 || svs[3].Elevation != 0 {
+		t.Errorf("without fill: %d filled, %d mismatches, hdop %g", c.Filled, c.Mismatches, n.HDOP)
+	}
+	c = CheckGeometry(&n, svs, CheckOptions{Fill: true, AzElTolerance: 1, DopTolerance: 0.1})
+	if c.Filled != 3+2*5 || c.Mismatches != 0 {
+		t.Errorf("with fill: %d filled, %d mismatches, want 13 and 0", c.Filled, c.Mismatches)
+	}
+	if math.Abs(float64(n.HDOP)-4.0/3.0) > 1e-4 || math.Abs(float64(svs[2].Azimuth)-120) > 1e-3 ||
+		math.Abs(float64(svs[4].Elevation)+5) > 1e-3 {
+		t.Errorf("filled hdop %g, prn 3 azimuth %g, prn 5 elevation %g", n.HDOP, svs[2].Azimuth, svs[4].Elevation)
+	}
+
+	// 3. nothing to check without a fix
+	n, svs = geometry()
+	n.Latitude, n.Longitude, n.Altitude = 0, 0, 0
+	if c := CheckGeometry(&n, svs, DefaultCheckOptions); len(c.Satellites) != 0 || c.PDOP.Computed != 0 {
+		t.Errorf("check without a fix %+v", c)
+	}
+}
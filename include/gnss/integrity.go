@@ -0,0 +1,266 @@
+package gnss
+
+import (
+	"math"
+	"slices"
+
+	"github.com/sturdivant20/sturdr-api/include/analysis"
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+// Integrity (RAIM) test settings
+type IntegrityOptions struct {
+	Sigma float64 // pseudorange error standard deviation [m]
+	Pfa   float64 // false alarm probability of the test
+	Pmd   float64 // missed detection probability of the protection levels
+	HAL   float64 // horizontal alert limit [m] (0 disables the limit)
+	VAL   float64 // vertical alert limit [m]
+	Mask  float64 // elevation mask of the satellites used [deg]
+}
+
+// Integrity settings of a test without settings
+var DefaultIntegrityOptions = IntegrityOptions{Sigma: 5.0, Pfa: 1e-5, Pmd: 1e-3, HAL: 40.0, VAL: 50.0, Mask: 5.0}
+
+// Integrity test results of an epoch
+const (
+	IntegrityOk          = "ok"          // the residuals pass the test
+	IntegrityExcluded    = "excluded"    // the test failed and passes without one satellite
+	IntegrityFault       = "fault"       // the test failed and no single exclusion passes
+	IntegrityUnavailable = "unavailable" // fewer than 5 satellites or a singular geometry (nothing to test)
+)
+
+// Least-squares residual of one satellite
+type SatelliteIntegrity struct {
+	PRN       uint8   `json:"prn"`
+	Elevation float64 `json:"elevation"` // [deg]
+	Residual  float64 `json:"residual"`  // post-fit pseudorange residual [m] (the prediction error when excluded)
+	Slope     float64 `json:"slope"`     // horizontal position error per unit of the square root of the statistic [m]
+	Excluded  bool    `json:"excluded"`
+}
+
+// Integrity of one epoch
+type Integrity struct {
+	Sequence   uint64               `json:"sequence"`
+	Week       uint16               `json:"week"`
+	ToW        float32              `json:"tow"`
+	Status     string               `json:"status"`
+	NSat       int                  `json:"n_sat"`      // satellites above the mask with a pseudorange
+	Statistic  float64              `json:"statistic"`  // sum of squared residuals over sigma^2 of the satellites kept
+	Threshold  float64              `json:"threshold"`  // chi-square detection threshold of the false alarm probability
+	HPL        float64              `json:"hpl"`        // horizontal protection level [m]
+	VPL        float64              `json:"vpl"`        // vertical protection level [m]
+	Available  bool                 `json:"available"`  // tested and the protection levels are within the alert limits
+	Excluded   *uint8               `json:"excluded"`   // prn removed by the fault exclusion (null when none)
+	Correction analysis.ENU         `json:"correction"` // least-squares position minus the navigation position [m]
+	Clock      float64              `json:"clock"`      // least-squares clock bias [m]
+	Satellites []SatelliteIntegrity `json:"satellites"`
+}
+
+func (i *Integrity) GpsTime() float64 {
+	return float64(i.Week)*604800 + float64(i.ToW)
+}
+
+// Whether the epoch failed the test (with or without a successful exclusion)
+func (i *Integrity) Failed() bool {
+	return i.Status == IntegrityFault || i.Status == IntegrityExcluded
+}
+
+// Linearized pseudorange of one satellite about the navigation position
+type measurement struct {
+	prn uint8
+	el  float64    // [deg]
+	h   [4]float64 // geometry row [-u_e, -u_n, -u_u, 1]
+	y   float64    // pseudorange minus the predicted range [m]
+}
+
+// Least-squares fit of a set of measurements
+type fit struct {
+	dx     [4]float64 // east, north, up and clock corrections [m]
+	sse    float64    // sum of squared residuals [m^2]
+	r      []float64  // residuals [m]
+	hslope []float64  // horizontal and vertical position error per unit of the residual norm
+	vslope []float64
+}
+
+// Receiver autonomous integrity monitoring of an epoch: the least-squares pseudorange residuals about the navigation
+// position are tested against a chi-square threshold, a failed test is retried without each satellite (when at least
+// 6 are tracked) and the protection levels bound the position error a fault at the missed detection probability causes
+func CheckIntegrity(n *navigation.Navigation, svs []satellite.Satellite, opts IntegrityOptions) Integrity {
+	res := Integrity{Sequence: n.Sequence, Week: n.Week, ToW: n.ToW, Status: IntegrityUnavailable,
+		Satellites: []SatelliteIntegrity{}}
+	user, ok := UserPosition(n)
+	if !ok {
+		return res
+	}
+
+	// 1. measurements of the satellites above the mask
+	var ms []measurement
+	for i := range svs {
+		sv := &svs[i]
+		if !HasPosition(sv) || sv.PSR == 0 {
+			continue
+		}
+		g := SatelliteGeometry(user, [3]float64{float64(sv.X), float64(sv.Y), float64(sv.Z)})
+		if g.Elevation*geodesy.Rad2Deg < opts.Mask {
+			continue
+		}
+		u := geodesy.Ned2Enu(g.LOS)
+		ms = append(ms, measurement{
+			prn: sv.PRN,
+			el:  g.Elevation * geodesy.Rad2Deg,
+			h:   [4]float64{-u[0], -u[1], -u[2], 1},
+			y:   float64(sv.PSR) - g.Range,
+		})
+	}
+	res.NSat = len(ms)
+	if len(ms) < 5 {
+		return res
+	}
+	all, ok := solve(ms)
+	if !ok {
+		return res
+	}
+
+	// 2. detection
+	sigma2 := opts.Sigma * opts.Sigma
+	threshold := analysis.ChiSquareQuantile(1-opts.Pfa, len(ms)-4)
+	best, excluded := all, -1
+	res.Status = IntegrityOk
+	if all.sse/sigma2 > threshold {
+		// 3. exclusion, the passing subset with the smallest statistic
+		res.Status = IntegrityFault
+		if len(ms) >= 6 {
+			sub_threshold := analysis.ChiSquareQuantile(1-opts.Pfa, len(ms)-5)
+			sub := make([]measurement, 0, len(ms)-1)
+			for i := range ms {
+				sub = append(append(sub[:0], ms[:i]...), ms[i+1:]...)
+				f, ok := solve(sub)
+				if !ok || f.sse/sigma2 > sub_threshold || (excluded >= 0 && f.sse >= best.sse) {
+					continue
+				}
+				best, excluded = f, i
+			}
+			if excluded >= 0 {
+				res.Status = IntegrityExcluded
+				res.Excluded = &ms[excluded].prn
+				threshold = sub_threshold
+			}
+		}
+	}
+	res.Statistic = best.sse / sigma2
+	res.Threshold = threshold
+	res.Correction = analysis.ENU{East: best.dx[0], North: best.dx[1], Up: best.dx[2]}
+	res.Clock = best.dx[3]
+
+	// 4. protection levels of the kept satellites (the non-centrality of a just missed fault is approximated by
+	// sqrt(threshold) plus the normal quantile of the missed detection probability)
+	bias := opts.Sigma * (math.Sqrt(threshold) + analysis.NormalQuantile(1-opts.Pmd))
+	res.HPL = bias * slices.Max(best.hslope)
+	res.VPL = bias * slices.Max(best.vslope)
+	res.Available = res.Status != IntegrityFault &&
+		(opts.HAL <= 0 || res.HPL <= opts.HAL) && (opts.VAL <= 0 || res.VPL <= opts.VAL)
+
+	// 5. residuals of every satellite against the kept solution
+	k := 0
+	for i := range ms {
+		s := SatelliteIntegrity{PRN: ms[i].prn, Elevation: ms[i].el}
+		if i == excluded {
+			s.Excluded = true
+			s.Residual = ms[i].y - dot4(ms[i].h, best.dx)
+		} else {
+			s.Residual = best.r[k]
+			s.Slope = opts.Sigma * best.hslope[k]
+			k++
+		}
+		res.Satellites = append(res.Satellites, s)
+	}
+	return res
+}
+
+// Least-squares fit of the measurements, with the slopes of the position error to the normalized residual of a
+// bias on each satellite (false for a singular geometry)
+func solve(ms []measurement) (fit, bool) {
+	var m [4][4]float64
+	for _, z := range ms {
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				m[i][j] += z.h[i] * z.h[j]
+			}
+		}
+	}
+	q, ok := Inv4(m)
+	if !ok {
+		return fit{}, false
+	}
+
+	// A = (H'H)^-1 H' maps the measurements to the corrections
+	f := fit{r: make([]float64, len(ms)), hslope: make([]float64, len(ms)), vslope: make([]float64, len(ms))}
+	a := make([][4]float64, len(ms))
+	for k, z := range ms {
+		for i := 0; i < 4; i++ {
+			a[k][i] = dot4(q[i], z.h)
+			f.dx[i] += a[k][i] * z.y
+		}
+	}
+	for k, z := range ms {
+		f.r[k] = z.y - dot4(z.h, f.dx)
+		f.sse += f.r[k] * f.r[k]
+
+		// a bias b on satellite k moves the position by b*a[k] and the residual norm by b*sqrt(1 - (HA)_kk)
+		s := math.Sqrt(max(1-dot4(z.h, a[k]), 1e-12))
+		f.hslope[k] = math.Hypot(a[k][0], a[k][1]) / s
+		f.vslope[k] = math.Abs(a[k][2]) / s
+	}
+	return f, true
+}
+
+func dot4(a [4]float64, b [4]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] + a[3]*b[3]
+}
+
+// Integrity of a span of epochs
+type IntegrityReport struct {
+	Epochs       int              `json:"epochs"`
+	Ok           int              `json:"ok"`
+	Excluded     int              `json:"excluded"`
+	Fault        int              `json:"fault"`
+	Unavailable  int              `json:"unavailable"`
+	Availability float64          `json:"availability"` // fraction of epochs within the alert limits
+	HPL          analysis.Summary `json:"hpl"`          // protection levels of the tested epochs [m]
+	VPL          analysis.Summary `json:"vpl"`
+	Results      []Integrity      `json:"results"`
+}
+
+// Count the results and summarize the protection levels of a span
+func Report(results []Integrity) IntegrityReport {
+	rep := IntegrityReport{Epochs: len(results), Results: results}
+	var hpl, vpl []float64
+	available := 0
+	for i := range results {
+		r := &results[i]
+		switch r.Status {
+		case IntegrityOk:
+			rep.Ok++
+		case IntegrityExcluded:
+			rep.Excluded++
+		case IntegrityFault:
+			rep.Fault++
+		default:
+			rep.Unavailable++
+			continue
+		}
+		if r.Available {
+			available++
+		}
+		hpl = append(hpl, r.HPL)
+		vpl = append(vpl, r.VPL)
+	}
+	if len(results) > 0 {
+		rep.Availability = float64(available) / float64(len(results))
+	}
+	rep.HPL = analysis.Summarize(hpl)
+	rep.VPL = analysis.Summarize(vpl)
+	return rep
+}
@@ -0,0 +1,144 @@
+package gnss
+
+import (
+	"testing"
+
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+// Satellites at the azimuth/elevations [deg] whose pseudoranges are the range plus a 1 km receiver clock bias and a
+// fault [m] on some prns
+func testIntegrityEpoch(azel [][2]float64, faults map[uint8]float64) (navigation.Navigation, []satellite.Satellite) {
+	n := testNavigation()
+	user, _ := UserPosition(&n)
+	svs := make([]satellite.Satellite, len(azel))
+	for i, ae := range azel {
+		svs[i] = testSatellite(&n, uint8(i), ae[0], ae[1])
+		g := SatelliteGeometry(user, [3]float64{float64(svs[i].X), float64(svs[i].Y), float64(svs[i].Z)})
+		svs[i].PSR = float32(g.Range + 1000 + faults[uint8(i)])
+	}
+	return n, svs
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	eight := [][2]float64{{0, 85}, {30, 40}, {90, 25}, {150, 55}, {200, 20}, {250, 45}, {310, 30}, {350, 15}}
+	five := eight[:5]
+	tests := []struct {
+		name     string
+		azel     [][2]float64
+		faults   map[uint8]float64
+		status   string
+		excluded int // -1 without an exclusion
+	}{
+		{"clean", eight, nil, IntegrityOk, -1},
+		{"bias on prn 0", eight, map[uint8]float64{0: 100}, IntegrityExcluded, 0},
+		{"bias on prn 5", eight, map[uint8]float64{5: 150}, IntegrityExcluded, 5},
+		{"five with a bias", five, map[uint8]float64{2: 300}, IntegrityFault, -1},
+		{"four satellites", eight[:4], nil, IntegrityUnavailable, -1},
+		{"one below the mask", append(eight[:4:4], [2]float64{100, 2}), nil, IntegrityUnavailable, -1},
+	}
+	for _, tt := range tests {
+		n, svs := testIntegrityEpoch(tt.azel, tt.faults)
+		c := CheckIntegrity(&n, svs, DefaultIntegrityOptions)
+		if c.Status != tt.status {
+			t.Errorf("%s: status %s (statistic %g, threshold %g), want %s", tt.name, c.Status, c.Statistic,
+				c.Threshold, tt.status)
+			continue
+		}
+		switch {
+		case tt.excluded < 0 && c.Excluded != nil:
+			t.Errorf("%s: excluded prn %d, want none", tt.name, *c.Excluded)
+		case tt.excluded >= 0 && (c.Excluded == nil || *c.Excluded != uint8(tt.excluded)):
+			t.Errorf("%s: excluded %v, want prn %d", tt.name, c.Excluded, tt.excluded)
+		}
+		if c.Failed() != (tt.status == IntegrityExcluded || tt.status == IntegrityFault) {
+			t.Errorf("%s: failed %v", tt.name, c.Failed())
+		}
+		if tt.status == IntegrityUnavailable {
+			if c.Available || len(c.Satellites) != 0 {
+				t.Errorf("%s: available %v with %d satellites", tt.name, c.Available, len(c.Satellites))
+			}
+			continue
+		}
+
+		// the kept solution absorbs the clock, and the excluded satellite carries its fault
+		if c.Statistic > c.Threshold && tt.status != IntegrityFault {
+			t.Errorf("%s: statistic %g above the threshold %g", tt.name, c.Statistic, c.Threshold)
+		}
+		if tt.status != IntegrityFault && (c.Clock < 998 || c.Clock > 1002) {
+			t.Errorf("%s: clock %g, want 1000", tt.name, c.Clock)
+		}
+		if len(c.Satellites) != len(tt.azel) || c.NSat != len(tt.azel) || c.HPL <= 0 || c.VPL <= 0 {
+			t.Errorf("%s: %d satellites, hpl %g, vpl %g", tt.name, len(c.Satellites), c.HPL, c.VPL)
+		}
+		for _, s := range c.Satellites {
+			if s.Excluded != (tt.excluded == int(s.PRN)) {
+				t.Errorf("%s: prn %d excluded %v", tt.name, s.PRN, s.Excluded)
+			}
+			if s.Excluded && (s.Residual < tt.faults[s.PRN]-5 || s.Residual > tt.faults[s.PRN]+5) {
+				t.Errorf("%s: excluded residual %g, want about %g", tt.name, s.Residual, tt.faults[s.PRN])
+			}
+		}
+	}
+
+	// no fix, nothing to test
+	n, svs := testIntegrityEpoch(eight, nil)
+	n.Latitude, n.Longitude, n.Altitude = 0, 0, 0
+	if c := CheckIntegrity(&n, svs, DefaultIntegrityOptions); c.Status != IntegrityUnavailable || c.NSat != 0 {
+		t.Errorf("without a fix: status %s with %d satellites", c.Status, c.NSat)
+	}
+}
+
+func TestIntegrityAlertLimits(t *testing.T) {
+	// the same clean epoch is available unless the alert limits are below its protection levels (about 55 and 75 m)
+	n, svs := testIntegrityEpoch([][2]float64{{0, 85}, {30, 40}, {90, 25}, {150, 55}, {200, 20}, {250, 45}}, nil)
+	opts := DefaultIntegrityOptions
+	opts.HAL, opts.VAL = 100, 100
+	c := CheckIntegrity(&n, svs, opts)
+	if !c.Available {
+		t.Fatalf("hpl %g, vpl %g not available", c.HPL, c.VPL)
+	}
+	opts.HAL = c.HPL / 2
+	if c := CheckIntegrity(&n, svs, opts); c.Available || c.Status != IntegrityOk {
+		t.Errorf("hal %g below the hpl: status %s available %v", opts.HAL, c.Status, c.Available)
+	}
+	opts.HAL, opts.VAL = 0, 0
+	if c := CheckIntegrity(&n, svs, opts); !c.Available {
+		t.Error("unavailable without alert limits")
+	}
+}
+
+func TestReport(t *testing.T) {
+	eight := [][2]float64{{0, 85}, {30, 40}, {90, 25}, {150, 55}, {200, 20}, {250, 45}, {310, 30}, {350, 15}}
+	opts := DefaultIntegrityOptions
+	opts.HAL, opts.VAL = 100, 100
+	var results []Integrity
+	for _, e := range []struct {
+		azel   [][2]float64
+		faults map[uint8]float64
+	}{
+		{eight, nil}, {eight, nil}, {eight, map[uint8]float64{3: 120}}, {eight[:5], map[uint8]float64{1: 300}},
+		{eight[:4], nil},
+	} {
+		n, svs := testIntegrityEpoch(e.azel, e.faults)
+		results = append(results, CheckIntegrity(&n, svs, opts))
+	}
+
+	rep := Report(results)
+	if rep.Epochs != 5 || rep.Ok != 2 || rep.Excluded != 1 || rep.Fault != 1 || rep.Unavailable != 1 {
+		t.Errorf("report %d epochs: %d ok, %d excluded, %d fault, %d unavailable, want 5: 2, 1, 1, 1", rep.Epochs,
+			rep.Ok, rep.Excluded, rep.Fault, rep.Unavailable)
+	}
+
+	// protection levels of the 4 tested epochs, the fault and the unavailable epoch are not available
+	if rep.HPL.Count != 4 || rep.VPL.Count != 4 {
+		t.Errorf("%d horizontal and %d vertical protection levels, want 4", rep.HPL.Count, rep.VPL.Count)
+	}
+	if rep.Availability != 3.0/5.0 {
+		t.Errorf("availability %g, want 0.6", rep.Availability)
+	}
+	if rep := Report(nil); rep.Epochs != 0 || rep.Availability != 0 {
+		t.Errorf("empty report %+v", rep)
+	}
+}
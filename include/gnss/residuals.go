@@ -0,0 +1,181 @@
+package gnss
+
+import (
+	"math"
+
+	"github.com/sturdivant20/sturdr-api/include/analysis"
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+const (
+	L1Frequency = 1575.42e6 // gps L1 / galileo E1 carrier frequency [Hz]
+	L1Lambda    = geodesy.C / L1Frequency
+)
+
+// Options of the residual computation
+type ResidualOptions struct {
+	Mask   float64 // elevation mask of the satellites used [deg]
+	Sagnac bool    // correct the predicted range for the earth rotation during the signal transit
+}
+
+// Predicted range/range-rate and measurement residuals of one satellite
+type Residual struct {
+	Week      uint16  `json:"week"`
+	ToW       float32 `json:"tow"`
+	PRN       uint8   `json:"prn"`
+	Elevation float64 `json:"elevation"` // [deg]
+	CNo       float64 `json:"cno"`       // [dB-Hz]
+	Range     float64 `json:"range"`     // predicted [m]
+	RangeRate float64 `json:"range_rate"`
+	PSR       float64 `json:"psr"`     // pseudorange minus the predicted range and clock bias [m]
+	Doppler   float64 `json:"doppler"` // pseudorange rate (-doppler * wavelength) minus the predicted range rate and clock drift [m/s]
+}
+
+// Common receiver clock bias and drift of one epoch
+type Clock struct {
+	Week  uint16  `json:"week"`
+	ToW   float32 `json:"tow"`
+	NSat  int     `json:"n_sat"`
+	Bias  float64 `json:"bias"`  // [m]
+	Drift float64 `json:"drift"` // [m/s]
+}
+
+// Residuals of one epoch
+type EpochResiduals struct {
+	Clock      Clock
+	Satellites []Residual
+}
+
+// Residual time series of one satellite
+type SatelliteResiduals struct {
+	PRN     uint8            `json:"prn"`
+	PSR     analysis.Summary `json:"psr"`
+	Doppler analysis.Summary `json:"doppler"`
+	Series  []Residual       `json:"series"`
+}
+
+// Residual time series of a span
+type Residuals struct {
+	Epochs     int                  `json:"epochs"`
+	Clock      []Clock              `json:"clock"`
+	Satellites []SatelliteResiduals `json:"satellites"` // ordered by prn
+}
+
+func (r *Residual) GpsTime() float64 {
+	return float64(r.Week)*604800 + float64(r.ToW)
+}
+
+func (c *Clock) GpsTime() float64 {
+	return float64(c.Week)*604800 + float64(c.ToW)
+}
+
+// Pseudorange and doppler residuals of the satellites above the mask, the clock bias and drift are the mean of the
+// measurements minus the predictions (false when the receiver has no fix or no satellite is usable)
+func EpochResidual(n *navigation.Navigation, svs []satellite.Satellite, opts ResidualOptions) (EpochResiduals, bool) {
+	e := EpochResiduals{Clock: Clock{Week: n.Week, ToW: n.ToW}}
+	user, ok := UserPosition(n)
+	if !ok {
+		return e, false
+	}
+	user_xyz := geodesy.Lla2Ecef(user)
+	user_vel := geodesy.Ned2EcefVec([3]float64{float64(n.Vn), float64(n.Ve), float64(n.Vd)}, user[0], user[1])
+
+	// 1. predicted range and range rate of every usable satellite
+	var n_psr, n_dop int
+	for i := range svs {
+		sv := &svs[i]
+		if !HasPosition(sv) || sv.PSR == 0 {
+			continue
+		}
+		pos := [3]float64{float64(sv.X), float64(sv.Y), float64(sv.Z)}
+		vel := [3]float64{float64(sv.Vx), float64(sv.Vy), float64(sv.Vz)}
+		g := SatelliteGeometry(user, pos)
+		if g.Elevation*geodesy.Rad2Deg < opts.Mask {
+			continue
+		}
+		rng := g.Range
+		if opts.Sagnac {
+			rng += geodesy.OmegaE * (pos[0]*user_xyz[1] - pos[1]*user_xyz[0]) / geodesy.C
+		}
+		rng_rate := 0.0
+		for k := range 3 {
+			rng_rate += (vel[k] - user_vel[k]) * g.Unit[k]
+		}
+
+		// measured minus predicted (the clocks are removed below)
+		r := Residual{
+			Week:      sv.Week,
+			ToW:       sv.ToW,
+			PRN:       sv.PRN,
+			Elevation: g.Elevation * geodesy.Rad2Deg,
+			CNo:       float64(sv.CNo),
+			Range:     rng,
+			RangeRate: rng_rate,
+			PSR:       float64(sv.PSR) - rng,
+			Doppler:   math.NaN(),
+		}
+		e.Clock.Bias += r.PSR
+		n_psr++
+		if sv.Doppler != 0 {
+			r.Doppler = -float64(sv.Doppler)*L1Lambda - rng_rate
+			e.Clock.Drift += r.Doppler
+			n_dop++
+		}
+		e.Satellites = append(e.Satellites, r)
+	}
+	if n_psr == 0 {
+		return e, false
+	}
+
+	// 2. common clock bias and drift
+	e.Clock.NSat = n_psr
+	e.Clock.Bias /= float64(n_psr)
+	if n_dop > 0 {
+		e.Clock.Drift /= float64(n_dop)
+	}
+	for i := range e.Satellites {
+		r := &e.Satellites[i]
+		r.PSR -= e.Clock.Bias
+		if math.IsNaN(r.Doppler) {
+			r.Doppler = 0 // no doppler measurement
+		} else {
+			r.Doppler -= e.Clock.Drift
+		}
+	}
+	return e, true
+}
+
+// Group the residuals of consecutive epochs into one time series per prn
+func ResidualSeries(epochs []EpochResiduals) Residuals {
+	out := Residuals{Epochs: len(epochs), Clock: make([]Clock, len(epochs)), Satellites: []SatelliteResiduals{}}
+	by_prn := make(map[uint8][]Residual)
+	for i := range epochs {
+		out.Clock[i] = epochs[i].Clock
+		for _, r := range epochs[i].Satellites {
+			by_prn[r.PRN] = append(by_prn[r.PRN], r)
+		}
+	}
+	for prn := range 256 {
+		series, ok := by_prn[uint8(prn)]
+		if !ok {
+			continue
+		}
+		psr := make([]float64, len(series))
+		var dop []float64
+		for i := range series {
+			psr[i] = series[i].PSR
+			if series[i].Doppler != 0 { // epochs without a doppler measurement
+				dop = append(dop, series[i].Doppler)
+			}
+		}
+		out.Satellites = append(out.Satellites, SatelliteResiduals{
+			PRN:     uint8(prn),
+			PSR:     analysis.Summarize(psr),
+			Doppler: analysis.Summarize(dop),
+			Series:  series,
+		})
+	}
+	return out
+}
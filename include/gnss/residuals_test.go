@@ -0,0 +1,149 @@
+package gnss
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+// Epoch whose pseudoranges and dopplers carry only a receiver clock bias [m] and drift [m/s] (the satellites move
+// at 3 km/s, the user at a few m/s)
+func testResidualEpoch(bias, drift float64, sagnac bool) (navigation.Navigation, []satellite.Satellite) {
+	n := testNavigation()
+	n.Vn, n.Ve, n.Vd = 12, -5, 0.5
+	user, _ := UserPosition(&n)
+	user_xyz := geodesy.Lla2Ecef(user)
+	user_vel := geodesy.Ned2EcefVec([3]float64{12, -5, 0.5}, user[0], user[1])
+
+	var svs []satellite.Satellite
+	for i, ae := range [][2]float64{{0, 80}, {60, 45}, {135, 20}, {210, 35}, {300, 15}, {20, 3}} {
+		sv := testSatellite(&n, uint8(i), ae[0], ae[1])
+		sv.Vx, sv.Vy, sv.Vz = float32(3000*math.Cos(float64(i))), float32(3000*math.Sin(float64(i))), 500
+		sv.CNo = 45
+
+		pos := [3]float64{float64(sv.X), float64(sv.Y), float64(sv.Z)}
+		g := SatelliteGeometry(user, pos)
+		rng := g.Range
+		if sagnac {
+			rng += geodesy.OmegaE * (pos[0]*user_xyz[1] - pos[1]*user_xyz[0]) / geodesy.C
+		}
+		rng_rate := (float64(sv.Vx)-user_vel[0])*g.Unit[0] + (float64(sv.Vy)-user_vel[1])*g.Unit[1] +
+			(float64(sv.Vz)-user_vel[2])*g.Unit[2]
+		sv.PSR = float32(rng + bias)
+		sv.Doppler = float32(-(rng_rate + drift) / L1Lambda)
+		svs = append(svs, sv)
+	}
+	return n, svs
+}
+
+func TestEpochResidual(t *testing.T) {
+	tests := []struct {
+		bias, drift float64
+		sagnac      bool
+	}{
+		{0, 0, false},
+		{12345.6, -78.9, false},
+		{-3.2e5, 150, true},
+	}
+	for _, tt := range tests {
+		n, svs := testResidualEpoch(tt.bias, tt.drift, tt.sagnac)
+		e, ok := EpochResidual(&n, svs, ResidualOptions{Mask: 5, Sagnac: tt.sagnac})
+		if !ok {
+			t.Fatalf("bias %g: no residuals", tt.bias)
+		}
+
+		// the satellite at 3 deg is below the mask, the float32 pseudoranges round to about a meter
+		if e.Clock.NSat != 5 || len(e.Satellites) != 5 {
+			t.Errorf("bias %g: %d satellites used, want 5", tt.bias, e.Clock.NSat)
+		}
+		if math.Abs(e.Clock.Bias-tt.bias) > 1 || math.Abs(e.Clock.Drift-tt.drift) > 1e-2 {
+			t.Errorf("clock bias %g drift %g, want %g and %g", e.Clock.Bias, e.Clock.Drift, tt.bias, tt.drift)
+		}
+		for i, r := range e.Satellites {
+			if math.Abs(r.PSR) > 2 || math.Abs(r.Doppler) > 1e-2 || math.Abs(r.Range-float64(svs[i].PSR)+tt.bias) > 2 {
+				t.Errorf("bias %g prn %d: psr residual %g, doppler residual %g, range %g", tt.bias, r.PRN, r.PSR,
+					r.Doppler, r.Range)
+			}
+		}
+	}
+}
+
+func TestEpochResidualSagnac(t *testing.T) {
+	// without the correction the earth rotation stays in the residuals (tens of meters, minus their mean)
+	n, svs := testResidualEpoch(100, 0, true)
+	e, _ := EpochResidual(&n, svs, ResidualOptions{Mask: 5, Sagnac: false})
+	worst := 0.0
+	for _, r := range e.Satellites {
+		worst = max(worst, math.Abs(r.PSR))
+	}
+	if worst < 2 {
+		t.Errorf("largest residual without the sagnac correction %g m, want the earth rotation to show", worst)
+	}
+}
+
+func TestEpochResidualMissing(t *testing.T) {
+	n, svs := testResidualEpoch(50, 2, false)
+
+	// a satellite without a pseudorange is skipped, one without a doppler keeps a zero doppler residual and is left
+	// out of the drift
+	svs[1].PSR = 0
+	svs[2].Doppler = 0
+	e, ok := EpochResidual(&n, svs, ResidualOptions{Mask: 5})
+	if !ok || e.Clock.NSat != 4 {
+		t.Fatalf("ok %v with %d satellites, want 4", ok, e.Clock.NSat)
+	}
+	if math.Abs(e.Clock.Drift-2) > 1e-2 {
+		t.Errorf("drift %g, want 2", e.Clock.Drift)
+	}
+	for _, r := range e.Satellites {
+		if r.PRN == 1 {
+			t.Error("satellite without a pseudorange used")
+		}
+		if r.PRN == 2 && r.Doppler != 0 {
+			t.Errorf("doppler residual without a doppler %g", r.Doppler)
+		}
+	}
+
+	// no fix, or nothing above the mask
+	n.Latitude, n.Longitude, n.Altitude = 0, 0, 0
+	if _, ok := EpochResidual(&n, svs, ResidualOptions{Mask: 5}); ok {
+		t.Error("residuals without a fix")
+	}
+	n, svs = testResidualEpoch(50, 2, false)
+	if _, ok := EpochResidual(&n, svs, ResidualOptions{Mask: 85}); ok {
+		t.Error("residuals without a satellite above the mask")
+	}
+}
+
+func TestResidualSeries(t *testing.T) {
+	var epochs []EpochResiduals
+	for k := 0; k < 3; k++ {
+		n, svs := testResidualEpoch(float64(k)*10, 1, false)
+		n.ToW += float32(k)
+		if k == 1 {
+			svs = svs[2:] // prn 0 and 1 lost for an epoch
+		}
+		e, _ := EpochResidual(&n, svs, ResidualOptions{Mask: 5})
+		epochs = append(epochs, e)
+	}
+	r := ResidualSeries(epochs)
+	if r.Epochs != 3 || len(r.Clock) != 3 || r.Clock[2].ToW != 1002 {
+		t.Errorf("%d epochs with %d clocks, want 3", r.Epochs, len(r.Clock))
+	}
+	if len(r.Satellites) != 5 {
+		t.Fatalf("%d satellites, want 5", len(r.Satellites))
+	}
+	for i, s := range r.Satellites {
+		want := 3
+		if s.PRN < 2 {
+			want = 2
+		}
+		if s.PRN != uint8(i) || len(s.Series) != want || s.PSR.Count != uint64(want) || math.Abs(s.PSR.Mean) > 2 {
+			t.Errorf("prn %d: %d records with a mean psr residual of %g, want prn %d with %d", s.PRN, len(s.Series),
+				s.PSR.Mean, i, want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Logging settings (EX: Options{Format: "json", Level: "info", Levels: map[string]string{"telemetry": "debug"}})
+type Options struct {
+	Format string            // "text" or "json"
+	Level  string            // default level of every source
+	Levels map[string]string // level of individual sources (packages, "gui", "http", ...)
+}
+
+// base handler every source writes through, swapped by Setup
+var base atomic.Pointer[slog.Handler]
+
+// levels by source
+var (
+	mu       sync.RWMutex
+	fallback = slog.LevelInfo
+	levels   = map[string]slog.Level{}
+)
+
+// text to stderr until Setup is called
+func init() {
+	h := slog.Handler(slog.NewTextHandler(os.Stderr, nil))
+	base.Store(&h)
+}
+
+// Configure the output format and levels of every logger (loggers created earlier follow the new settings)
+func Setup(w io.Writer, opts Options) error {
+	// 1. levels
+	def, err := ParseLevel(opts.Level)
+	if err != nil {
+		return err
+	}
+	by_source := make(map[string]slog.Level, len(opts.Levels))
+	for src, s := range opts.Levels {
+		l, err := ParseLevel(s)
+		if err != nil {
+			return fmt.Errorf("source '%s': %w", src, err)
+		}
+		by_source[src] = l
+	}
+
+	// 2. output format (filtering happens per source, so the base handler passes everything)
+	h_opts := &slog.HandlerOptions{Level: slog.Level(math.MinInt)}
+	var h slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "json":
+		h = slog.NewJSONHandler(w, h_opts)
+	case "text", "":
+		h = slog.NewTextHandler(w, h_opts)
+	default:
+		return fmt.Errorf("unknown log format '%s'", opts.Format)
+	}
+
+	mu.Lock()
+	fallback, levels = def, by_source
+	mu.Unlock()
+	base.Store(&h)
+
+	// standard library "log" calls and slog.Default go through the "main" source
+	slog.SetDefault(For("main"))
+	return nil
+}
+
+// Parse "debug", "info", "warn" or "error" (empty = info)
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := l.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("unknown log level '%s'", s)
+	}
+	return l, nil
+}
+
+// Logger of a source, every record is tagged with "source=<name>"
+func For(source string) *slog.Logger {
+	return slog.New(&handler{source: source})
+}
+
+// Minimum level of a source
+func levelOf(source string) slog.Level {
+	mu.RLock()
+	defer mu.RUnlock()
+	if l, ok := levels[source]; ok {
+		return l
+	}
+	return fallback
+}
+
+// --- handler ---
+
+// Source handler, resolves the base handler and level when a record is written
+type handler struct {
+	source string
+	ops    []func(slog.Handler) slog.Handler // With/WithGroup calls, replayed onto the base handler
+}
+
+func (h *handler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= levelOf(h.source)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	out := (*base.Load()).WithAttrs([]slog.Attr{slog.String("source", h.source)})
+	for _, op := range h.ops {
+		out = op(out)
+	}
+	if id := RequestId(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return out.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(b slog.Handler) slog.Handler { return b.WithAttrs(attrs) })
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return h.with(func(b slog.Handler) slog.Handler { return b.WithGroup(name) })
+}
+
+func (h *handler) with(op func(slog.Handler) slog.Handler) *handler {
+	ops := make([]func(slog.Handler) slog.Handler, len(h.ops), len(h.ops)+1)
+	copy(ops, h.ops)
+	return &handler{source: h.source, ops: append(ops, op)}
+}
+
+// --- request id ---
+
+type requestIdKey struct{}
+
+// Attach a request id to a context, records logged with it carry "request_id=<id>"
+func WithRequestId(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, id)
+}
+
+// Request id of a context (empty if none)
+func RequestId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIdKey{}).(string)
+	return id
+}
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// --- http ---
+
+var HttpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "sturdr_http_requests_total",
+	Help: "Number of http requests by route, method and status code.",
+}, []string{"route", "method", "code"})
+
+var HttpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "sturdr_http_request_duration_seconds",
+	Help:    "Http request latency by route and method.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"route", "method"})
+
+// --- sql ---
+
+var sqlDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "sturdr_sql_statement_duration_seconds",
+	Help:    "Prepared sql statement latency by statement name.",
+	Buckets: []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+}, []string{"statement"})
+
+// Record the latency of a prepared statement (EX: "defer metrics.ObserveStatement("create_navigation", time.Now())")
+func ObserveStatement(name string, start time.Time) {
+	sqlDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+}
+
+// --- ingest ---
+
+var IngestEpochs = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "sturdr_ingest_epochs_total",
+	Help: "Number of navigation epochs stored.",
+})
+
+// --- receiver (epochs created over http or grpc or by the generator, replayed epochs are not reported) ---
+
+var NSat = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "sturdr_receiver_n_sat",
+	Help: "Number of satellites used in the latest navigation solution.",
+})
+
+var Dop = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sturdr_receiver_dop",
+	Help: "Dilution of precision of the latest navigation solution.",
+}, []string{"type"})
+
+var Tracked = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sturdr_receiver_tracked_satellites",
+	Help: "Number of satellites in the latest epoch by constellation.",
+}, []string{"constellation"})
+
+var MeanCNo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sturdr_receiver_cno_mean_dbhz",
+	Help: "Mean C/No of the latest epoch by constellation.",
+}, []string{"constellation"})
+
+var MinCNo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sturdr_receiver_cno_min_dbhz",
+	Help: "Minimum C/No of the latest epoch by constellation.",
+}, []string{"constellation"})
+
+var LastEpoch = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "sturdr_receiver_last_epoch_timestamp_seconds",
+	Help: "Unix time the receiver created the latest telemetry epoch.",
+})
+
+// --- geometry ---
+
+var GeometryMismatches = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "sturdr_geometry_mismatches_total",
+	Help: "Receiver azimuth, elevation and dop values that differ from the recomputed geometry.",
+}, []string{"value"})
+
+var GeometryFilled = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "sturdr_geometry_filled_total",
+	Help: "Zero receiver azimuth, elevation and dop values filled in from the recomputed geometry.",
+})
+
+// --- integrity ---
+
+var IntegrityEpochs = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "sturdr_integrity_epochs_total",
+	Help: "Created telemetry epochs by integrity test result (ok, excluded, fault or unavailable).",
+}, []string{"status"})
+
+var IntegrityProtectionLevel = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sturdr_integrity_protection_level_meters",
+	Help: "Protection level of the latest tested telemetry epoch.",
+}, []string{"axis"})
+
+var IntegrityAvailable = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "sturdr_integrity_available",
+	Help: "Whether the latest telemetry epoch passed the integrity test within the alert limits (1) or not (0).",
+})
+
+// --- carrier phase ---
+
+var CarrierEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "sturdr_carrier_phase_events_total",
+	Help: "Cycle slips and phase lock losses detected in created telemetry.",
+}, []string{"kind"})
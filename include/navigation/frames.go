@@ -0,0 +1,168 @@
+package navigation
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/sturdivant20/sturdr-api/include/decimate"
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+)
+
+// Navigation with the position and velocity in the ECEF frame ("frame=ecef")
+type Ecef struct {
+	Sequence uint64  `json:"sequence"`
+	Week     uint16  `json:"week"`
+	ToW      float32 `json:"tow"`
+	NSat     uint8   `json:"n_sat"`
+	X        float64 `json:"x"` // [m]
+	Y        float64 `json:"y"`
+	Z        float64 `json:"z"`
+	Vx       float64 `json:"vx"` // [m/s]
+	Vy       float64 `json:"vy"`
+	Vz       float64 `json:"vz"`
+	Roll     float32 `json:"roll"`
+	Pitch    float32 `json:"pitch"`
+	Yaw      float32 `json:"yaw"`
+	PDOP     float32 `json:"pdop"`
+	HDOP     float32 `json:"hdop"`
+	VDOP     float32 `json:"vdop"`
+}
+
+// Navigation with the position and velocity in the local level frame of an origin ("frame=enu")
+type Enu struct {
+	Sequence uint64  `json:"sequence"`
+	Week     uint16  `json:"week"`
+	ToW      float32 `json:"tow"`
+	NSat     uint8   `json:"n_sat"`
+	East     float64 `json:"east"` // [m]
+	North    float64 `json:"north"`
+	Up       float64 `json:"up"`
+	Ve       float64 `json:"ve"` // [m/s]
+	Vn       float64 `json:"vn"`
+	Vu       float64 `json:"vu"`
+	Roll     float32 `json:"roll"`
+	Pitch    float32 `json:"pitch"`
+	Yaw      float32 `json:"yaw"`
+	PDOP     float32 `json:"pdop"`
+	HDOP     float32 `json:"hdop"`
+	VDOP     float32 `json:"vdop"`
+}
+
+func (n *Ecef) GpsTime() float64 {
+	return float64(n.Week)*604800 + float64(n.ToW)
+}
+
+func (n *Enu) GpsTime() float64 {
+	return float64(n.Week)*604800 + float64(n.ToW)
+}
+
+// Geodetic position [rad, rad, m] and NED velocity [m/s]
+func (n *Navigation) lla() ([3]float64, [3]float64) {
+	lla := [3]float64{float64(n.Latitude) * geodesy.Deg2Rad, float64(n.Longitude) * geodesy.Deg2Rad, float64(n.Altitude)}
+	return lla, [3]float64{float64(n.Vn), float64(n.Ve), float64(n.Vd)}
+}
+
+// Position and velocity in the ECEF frame
+func (n *Navigation) Ecef() Ecef {
+	lla, ned := n.lla()
+	xyz := geodesy.Lla2Ecef(lla)
+	v := geodesy.Ned2EcefVec(ned, lla[0], lla[1])
+	return Ecef{
+		Sequence: n.Sequence, Week: n.Week, ToW: n.ToW, NSat: n.NSat,
+		X: xyz[0], Y: xyz[1], Z: xyz[2], Vx: v[0], Vy: v[1], Vz: v[2],
+		Roll: n.Roll, Pitch: n.Pitch, Yaw: n.Yaw, PDOP: n.PDOP, HDOP: n.HDOP, VDOP: n.VDOP,
+	}
+}
+
+// Position and velocity in the local level frame of an origin (the velocity is rotated into the origin's frame)
+func (n *Navigation) Enu(o geodesy.Origin) Enu {
+	lla, ned := n.lla()
+	enu := o.Lla2Enu(lla)
+	v := o.Ned2EnuVec(ned, lla[0], lla[1])
+	return Enu{
+		Sequence: n.Sequence, Week: n.Week, ToW: n.ToW, NSat: n.NSat,
+		East: enu[0], North: enu[1], Up: enu[2], Ve: v[0], Vn: v[1], Vu: v[2],
+		Roll: n.Roll, Pitch: n.Pitch, Yaw: n.Yaw, PDOP: n.PDOP, HDOP: n.HDOP, VDOP: n.VDOP,
+	}
+}
+
+// Output frame of navigation positions, "frame=" is "lla" (stored), "ecef" or "enu" relative to "origin="
+type Frame struct {
+	Name   string
+	Origin geodesy.Origin
+}
+
+func ParseFrame(q url.Values) (Frame, error) {
+	f := Frame{Name: q.Get("frame")}
+	s_origin := q.Get("origin")
+	if f.Name == "" {
+		f.Name = "lla"
+		if s_origin != "" {
+			f.Name = "enu" // an origin implies its local level frame
+		}
+	}
+	switch f.Name {
+	case "lla", "ecef":
+		if s_origin != "" {
+			return Frame{}, fmt.Errorf("origin is only used by frame=enu")
+		}
+	case "enu":
+		if s_origin == "" {
+			return Frame{}, fmt.Errorf("frame=enu needs origin=latitude,longitude,altitude")
+		}
+		var err error
+		if f.Origin, err = geodesy.ParseOrigin(s_origin); err != nil {
+			return Frame{}, err
+		}
+	default:
+		return Frame{}, fmt.Errorf("frame must be 'lla', 'ecef' or 'enu', got '%s'", f.Name)
+	}
+	return f, nil
+}
+
+// Whether the stored records are converted (the conversion needs every column)
+func (f Frame) Converts() bool {
+	return f.Name != "lla"
+}
+
+// Zero record of the frame (for "fields=")
+func (f Frame) Record() any {
+	switch f.Name {
+	case "ecef":
+		return Ecef{}
+	case "enu":
+		return Enu{}
+	}
+	return Navigation{}
+}
+
+// Height field of the frame (the default shape preserved by downsampling)
+func (f Frame) Height() string {
+	switch f.Name {
+	case "ecef":
+		return "z"
+	case "enu":
+		return "up"
+	}
+	return "altitude"
+}
+
+// Convert stored records into the frame and downsample them
+func (f Frame) Convert(items []Navigation, opts decimate.Options) (any, error) {
+	switch f.Name {
+	case "ecef":
+		return convert(items, (*Navigation).Ecef, (*Ecef).GpsTime, opts)
+	case "enu":
+		to_enu := func(n *Navigation) Enu { return n.Enu(f.Origin) }
+		return convert(items, to_enu, (*Enu).GpsTime, opts)
+	}
+	return decimate.Series(items, (*Navigation).GpsTime, opts)
+}
+
+func convert[T any](items []Navigation, to func(*Navigation) T, at func(*T) float64, opts decimate.Options) ([]T, error) {
+	out := make([]T, len(items))
+	for i := range items {
+		out[i] = to(&items[i])
+	}
+	return decimate.Series(out, at, opts)
+}
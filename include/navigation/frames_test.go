@@ -0,0 +1,133 @@
+package navigation
+
+import (
+	"math"
+	"net/url"
+	"testing"
+
+	"github.com/sturdivant20/sturdr-api/include/decimate"
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+)
+
+func testNavigation() Navigation {
+	return Navigation{
+		Sequence: 7, Week: 2300, ToW: 1000.5, NSat: 9,
+		Latitude: 32.5864, Longitude: -85.4944, Altitude: 200, Vn: 1.5, Ve: -0.5, Vd: 0.1,
+		Roll: 1, Pitch: 2, Yaw: 45, PDOP: 1.8, HDOP: 1.0, VDOP: 1.5,
+	}
+}
+
+func TestEcefRoundTrip(t *testing.T) {
+	for _, n := range []Navigation{
+		testNavigation(),
+		{Latitude: 0, Longitude: 0, Altitude: 0, Vn: 1},
+		{Latitude: -33.8688, Longitude: 151.2093, Altitude: 58, Ve: 10, Vd: -2},
+		{Latitude: 89.9, Longitude: 179.9, Altitude: 10000, Vn: -250, Ve: 30, Vd: 5},
+	} {
+		e := n.Ecef()
+
+		// back to the stored geodetic position
+		lla := geodesy.Ecef2Lla([3]float64{e.X, e.Y, e.Z})
+		if math.Abs(lla[0]*geodesy.Rad2Deg-float64(n.Latitude)) > 1e-9 ||
+			math.Abs(lla[1]*geodesy.Rad2Deg-float64(n.Longitude)) > 1e-9 || math.Abs(lla[2]-float64(n.Altitude)) > 1e-3 {
+			t.Errorf("(%g, %g, %g): ecef round trip (%.9f, %.9f, %.4f)", n.Latitude, n.Longitude, n.Altitude,
+				lla[0]*geodesy.Rad2Deg, lla[1]*geodesy.Rad2Deg, lla[2])
+		}
+
+		// the velocity is only rotated
+		ned := geodesy.Ecef2NedVec([3]float64{e.Vx, e.Vy, e.Vz}, lla[0], lla[1])
+		want := [3]float64{float64(n.Vn), float64(n.Ve), float64(n.Vd)}
+		for i := range ned {
+			if math.Abs(ned[i]-want[i]) > 1e-9 {
+				t.Errorf("(%g, %g): ned velocity %v, want %v", n.Latitude, n.Longitude, ned, want)
+				break
+			}
+		}
+	}
+
+	// every other field is kept
+	n := testNavigation()
+	e := n.Ecef()
+	if e.Sequence != n.Sequence || e.Week != n.Week || e.ToW != n.ToW || e.NSat != n.NSat || e.Yaw != n.Yaw ||
+		e.PDOP != n.PDOP || e.GpsTime() != n.GpsTime() {
+		t.Errorf("ecef record %+v does not keep the fields of %+v", e, n)
+	}
+}
+
+func TestEnu(t *testing.T) {
+	n := testNavigation()
+
+	// at its own origin the position is zero and the velocity keeps its components
+	lla, _ := n.lla()
+	e := n.Enu(geodesy.NewOrigin(lla))
+	if math.Abs(e.East) > 1e-6 || math.Abs(e.North) > 1e-6 || math.Abs(e.Up) > 1e-6 {
+		t.Errorf("position at the origin %g, %g, %g, want 0", e.East, e.North, e.Up)
+	}
+	if math.Abs(e.Ve+0.5) > 1e-6 || math.Abs(e.Vn-1.5) > 1e-6 || math.Abs(e.Vu+0.1) > 1e-6 {
+		t.Errorf("velocity at the origin %g, %g, %g, want -0.5, 1.5, -0.1", e.Ve, e.Vn, e.Vu)
+	}
+
+	// from an origin 100 m below and about 1 km west, and back
+	o, err := geodesy.ParseOrigin("32.5864,-85.5051,100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e = n.Enu(o)
+	if math.Abs(e.East-1002) > 5 || math.Abs(e.North) > 1 || math.Abs(e.Up-100) > 0.5 {
+		t.Errorf("position %g, %g, %g, want about 1002, 0, 100", e.East, e.North, e.Up)
+	}
+	back := o.Enu2Lla([3]float64{e.East, e.North, e.Up})
+	if math.Abs(back[0]-lla[0]) > 1e-12 || math.Abs(back[1]-lla[1]) > 1e-12 || math.Abs(back[2]-lla[2]) > 1e-4 {
+		t.Errorf("enu round trip %v, want %v", back, lla)
+	}
+	if speed := math.Sqrt(e.Ve*e.Ve + e.Vn*e.Vn + e.Vu*e.Vu); math.Abs(speed-math.Sqrt(1.5*1.5+0.5*0.5+0.1*0.1)) > 1e-6 {
+		t.Errorf("speed %g changed by the rotation", speed)
+	}
+}
+
+func TestParseFrame(t *testing.T) {
+	tests := []struct {
+		query  string
+		name   string
+		height string
+		err    bool
+	}{
+		{"", "lla", "altitude", false},
+		{"frame=lla", "lla", "altitude", false},
+		{"frame=ecef", "ecef", "z", false},
+		{"frame=enu&origin=32.5864,-85.4944,200", "enu", "up", false},
+		{"origin=32.5864,-85.4944,200", "enu", "up", false},
+		{"frame=enu", "", "", true},
+		{"frame=ecef&origin=0,0,0", "", "", true},
+		{"frame=enu&origin=100,0,0", "", "", true},
+		{"frame=ned", "", "", true},
+	}
+	for _, tt := range tests {
+		q, _ := url.ParseQuery(tt.query)
+		f, err := ParseFrame(q)
+		if (err != nil) != tt.err {
+			t.Errorf("%q: error %v", tt.query, err)
+			continue
+		}
+		if err == nil && (f.Name != tt.name || f.Height() != tt.height || f.Converts() != (tt.name != "lla")) {
+			t.Errorf("%q: frame %s height %s, want %s and %s", tt.query, f.Name, f.Height(), tt.name, tt.height)
+		}
+	}
+}
+
+func TestFrameConvert(t *testing.T) {
+	items := make([]Navigation, 10)
+	for i := range items {
+		items[i] = testNavigation()
+		items[i].ToW = float32(i)
+	}
+	f, _ := ParseFrame(url.Values{"frame": {"ecef"}})
+	out, err := f.Convert(items, decimate.Options{Every: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecef, ok := out.([]Ecef)
+	if !ok || len(ecef) != 5 || ecef[1].ToW != 2 {
+		t.Errorf("converted %T of %d records, want 5 ecef records", out, len(ecef))
+	}
+}
@@ -1,32 +1,58 @@
 package navigation
 
 import (
-	"log"
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/sturdivant20/sturdr-api/include/decimate"
 	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/logging"
 )
 
+var logger = logging.For("navigation")
+
 type Handler struct {
 	service Service
+	replay  Service // nil without a replay controller
 }
 
 func NewHttpHandler(s Service) *Handler {
 	return &Handler{service: s}
 }
 
+// Attach the read-only replay view that serves reads with "source=replay"
+func (h *Handler) WithReplay(s Service) *Handler {
+	h.replay = s
+	return h
+}
+
+// Service of a read, the stored data or the replayed epochs played so far ("source=database" or "source=replay")
+func (h *Handler) reader(r *http.Request) (Service, error) {
+	switch source := r.URL.Query().Get("source"); source {
+	case "", "database":
+		return h.service, nil
+	case "replay":
+		if h.replay != nil {
+			return h.replay, nil
+		}
+		fallthrough
+	default:
+		return nil, fmt.Errorf("source must be 'database' or 'replay', got '%s'", source)
+	}
+}
+
 // Handle http create navigation json request
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	var n Navigation
-	if err := readRequest(r, &n); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := encoder.Read(r, &n); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
 		return
 	}
 
 	// create navigation using service
-	if err := h.service.createNavigation(r.Context(), n); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := h.service.CreateNavigation(r.Context(), n); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
@@ -39,15 +65,59 @@ func (h *Handler) Read(w http.ResponseWriter, r *http.Request) {
 	// request navigation by gps week and tow
 	week, tow, do_query := parseQuery(r)
 
-	// read queried navigation from table
-	n, err := h.service.readNavigation(r.Context(), week, tow, do_query)
+	// positions in "frame=" ("lla", "ecef", or "enu" relative to "origin=")
+	frame, err := ParseFrame(r.URL.Query())
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// limit the columns to "fields=" (EX: "tow,latitude,longitude"), named as in the frame
+	fields, err := encoder.ParseFields(r.URL.Query().Get("fields"), frame.Record())
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// downsample long histories ("every=", "bucket=" or "points=")
+	opts, err := decimate.ParseOptions(r.URL.Query(), frame.Height())
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	read_fields := fields
+	if frame.Converts() {
+		// every column is read for the conversion
+		read_fields = nil
+	} else if fields != nil && opts.Enabled() {
+		// the times (and the preserved field) are read even when they are not sent
+		spec := r.URL.Query().Get("fields") + ",week,tow," + opts.By
+		if read_fields, err = encoder.ParseFields(spec, Navigation{}); err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	// read queried navigation from table (or the replay)
+	service, err := h.reader(r)
 	if err != nil {
-		handleError(w, err, http.StatusNotFound)
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	n, err := service.ReadNavigation(r.Context(), week, tow, do_query, read_fields)
+	if err != nil {
+		handleError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	data, err := frame.Convert(n, opts)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
-	if err := writeResponse(w, r, n); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := encoder.Write(w, r, http.StatusOK, fields.Project(data)); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
 		return
 	}
 }
@@ -57,20 +127,20 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	// request navigation by id
 	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
 	if err != nil {
-		handleError(w, err, http.StatusBadRequest)
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	// decode incoming json data
 	var n Navigation
-	if err := readRequest(r, &n); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := encoder.Read(r, &n); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
 		return
 	}
 
 	// update held navigation
-	if err := h.service.updateNavigation(r.Context(), n, id); err != nil {
-		handleError(w, err, http.StatusExpectationFailed)
+	if err := h.service.UpdateNavigation(r.Context(), n, id); err != nil {
+		handleError(w, r, err, http.StatusExpectationFailed)
 		return
 	}
 
@@ -83,13 +153,13 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	// request navigation by id
 	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
 	if err != nil {
-		handleError(w, err, http.StatusBadRequest)
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	// delete held navigation
-	if err := h.service.deleteNavigation(r.Context(), id); err != nil {
-		handleError(w, err, http.StatusExpectationFailed)
+	if err := h.service.DeleteNavigation(r.Context(), id); err != nil {
+		handleError(w, r, err, http.StatusExpectationFailed)
 		return
 	}
 
@@ -98,47 +168,11 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 }
 
 // Reusable error handler
-func handleError(w http.ResponseWriter, e error, c int) {
-	log.Printf("Navigation error %d! %s", c, e.Error())
+func handleError(w http.ResponseWriter, r *http.Request, e error, c int) {
+	logger.WarnContext(r.Context(), "Navigation error!", "status", c, "error", e)
 	http.Error(w, e.Error(), c)
 }
 
-// Reusable read function
-func readRequest(r *http.Request, n *Navigation) error {
-	switch r.URL.Query().Get("format") {
-	case "binary":
-		if err := encoder.ReadBinary(r, n); err != nil {
-			return err
-		}
-	case "json":
-		fallthrough
-	default:
-		if err := encoder.ReadJson(r, n); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// Reusable write function
-func writeResponse(w http.ResponseWriter, r *http.Request, n []Navigation) error {
-	switch r.URL.Query().Get("format") {
-	case "binary":
-		if err := encoder.WriteBinary(w, http.StatusOK, n); err != nil {
-			return err
-		}
-	case "json":
-		fallthrough
-	default:
-		if err := encoder.WriteJson(w, http.StatusOK, n); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func parseQuery(r *http.Request) (uint16, float32, bool) {
 	query := r.URL.Query()
 	s_week := query.Get("week")
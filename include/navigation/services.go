@@ -3,16 +3,19 @@ package navigation
 import (
 	"context"
 	"database/sql"
-	"log"
 	"os"
 	"strings"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/metrics"
 )
 
 type Service interface {
-	createNavigation(ctx context.Context, n Navigation) error
-	readNavigation(ctx context.Context, week uint16, tow float32, do_query bool) ([]Navigation, error)
-	updateNavigation(ctx context.Context, n Navigation, id int64) error
-	deleteNavigation(ctx context.Context, id int64) error
+	CreateNavigation(ctx context.Context, n Navigation) error
+	ReadNavigation(ctx context.Context, week uint16, tow float32, do_query bool, fields *encoder.Fields) ([]Navigation, error)
+	UpdateNavigation(ctx context.Context, n Navigation, id int64) error
+	DeleteNavigation(ctx context.Context, id int64) error
 }
 
 type NavigationService struct {
@@ -22,6 +25,7 @@ type NavigationService struct {
 	ReadQueryStmt  *sql.Stmt
 	UpdateStmt     *sql.Stmt
 	DeleteStmt     *sql.Stmt
+	reads          map[string]string // sql of the read statements (projections select fewer columns)
 }
 
 // Initialize/create local navigation database file
@@ -29,7 +33,8 @@ func NewNavigationService(db *sql.DB, sql_fname string) Service {
 	// --- read sql commands from file ---
 	data, err := os.ReadFile(sql_fname)
 	if err != nil {
-		log.Fatalf("Error reading '%s': %s", sql_fname, err.Error())
+		logger.Error("Error reading SQL file!", "file", sql_fname, "error", err)
+		os.Exit(1)
 	}
 	cmd := strings.Split(string(data), ";")
 
@@ -38,7 +43,7 @@ func NewNavigationService(db *sql.DB, sql_fname string) Service {
 	statement.Exec()
 	statement = bindStatement(db, cmd[1], "create_navigation_index")
 	statement.Exec()
-	log.Printf("Created 'navigation' database table ...")
+	logger.Info("Created database table ...", "table", "navigation")
 
 	// --- bind statements ---
 	return &NavigationService{
@@ -47,50 +52,73 @@ func NewNavigationService(db *sql.DB, sql_fname string) Service {
 		ReadLatestStmt: bindStatement(db, strings.TrimSpace(cmd[3]), "read_latest_navigation"),
 		ReadQueryStmt:  bindStatement(db, strings.TrimSpace(cmd[4]), "read_queried_navigation"),
 		UpdateStmt:     bindStatement(db, strings.TrimSpace(cmd[5]), "update_navigation"),
-		DeleteStmt:     bindStatement(db, strings.TrimSpace(cmd[6]), "delete_navigation")}
+		DeleteStmt:     bindStatement(db, strings.TrimSpace(cmd[6]), "delete_navigation"),
+		reads: map[string]string{
+			"read_latest_navigation":  strings.TrimSpace(cmd[3]),
+			"read_queried_navigation": strings.TrimSpace(cmd[4])}}
 }
 
 // Add a navigation to the table
-func (s *NavigationService) createNavigation(ctx context.Context, n Navigation) error {
+func (s *NavigationService) CreateNavigation(ctx context.Context, n Navigation) error {
+	defer metrics.ObserveStatement("create_navigation", time.Now())
 	_, err := s.CreateStmt.ExecContext(ctx, n.Args()...)
+	if err == nil {
+		metrics.IngestEpochs.Inc()
+	}
 	return err
 }
 
-// Read navigation from the table
-func (s *NavigationService) readNavigation(
-	ctx context.Context, week uint16, tow float32, do_query bool) ([]Navigation, error) {
+// Read navigation from the table (only the selected columns when fields are given)
+func (s *NavigationService) ReadNavigation(
+	ctx context.Context, week uint16, tow float32, do_query bool, fields *encoder.Fields) ([]Navigation, error) {
 
+	// fetch latest row, or queried rows
+	stmt, name, args := s.ReadLatestStmt, "read_latest_navigation", []any{}
+	if do_query {
+		stmt, name, args = s.ReadQueryStmt, "read_queried_navigation", []any{week, tow}
+	}
+	defer metrics.ObserveStatement(name, time.Now())
 	var rows *sql.Rows
 	var err error
-	if do_query {
-		// fetch queried rows
-		rows, err = s.ReadQueryStmt.QueryContext(ctx, week, tow)
+	if fields == nil {
+		rows, err = stmt.QueryContext(ctx, args...)
 	} else {
-		// fetch latest row
-		rows, err = s.ReadLatestStmt.QueryContext(ctx)
+		var n Navigation
+		cols, _ := fields.Select(n.Args())
+		rows, err = s.db.QueryContext(ctx, encoder.SelectColumns(s.reads[name], cols), args...)
+	}
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
 	// add navigations to list
 	var items []Navigation
 	for rows.Next() {
 		var n Navigation
-		if err := rows.Scan(n.Args()...); err != nil {
+		targets := n.Args()
+		if fields != nil {
+			_, targets = fields.Select(targets)
+		}
+		if err := rows.Scan(targets...); err != nil {
 			return nil, err
 		}
 		items = append(items, n)
 	}
 
-	return items, err
+	return items, rows.Err()
 }
 
 // Update a navigation from the table
-func (s *NavigationService) updateNavigation(ctx context.Context, n Navigation, id int64) error {
+func (s *NavigationService) UpdateNavigation(ctx context.Context, n Navigation, id int64) error {
+	defer metrics.ObserveStatement("update_navigation", time.Now())
 	_, err := s.UpdateStmt.ExecContext(ctx, append(n.Args(), id)...)
 	return err
 }
 
 // Delete a navigation from the table
-func (s *NavigationService) deleteNavigation(ctx context.Context, id int64) error {
+func (s *NavigationService) DeleteNavigation(ctx context.Context, id int64) error {
+	defer metrics.ObserveStatement("delete_navigation", time.Now())
 	_, err := s.DeleteStmt.ExecContext(ctx, id)
 	return err
 }
@@ -99,7 +127,8 @@ func (s *NavigationService) deleteNavigation(ctx context.Context, id int64) erro
 func bindStatement(db *sql.DB, cmd string, name string) *sql.Stmt {
 	create_stmt, err := db.Prepare(cmd)
 	if err != nil {
-		log.Fatalf("Error preparing '%s' statement: %s", name, err.Error())
+		logger.Error("Error preparing statement!", "statement", name, "error", err)
+		os.Exit(1)
 	}
 	return create_stmt
 }
@@ -40,3 +40,8 @@ func (n *Navigation) Args() []any {
 		&n.VDOP,
 	}
 }
+
+// Seconds since the gps epoch
+func (n *Navigation) GpsTime() float64 {
+	return float64(n.Week)*604800 + float64(n.ToW)
+}
@@ -0,0 +1,302 @@
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// Load errors
+var (
+	ErrFile     = errors.New("replay file")              // outside the replay directory or missing
+	ErrDatabase = errors.New("not a telemetry database") // not sqlite or recorded with another schema
+)
+
+type Controller struct {
+	mu      sync.Mutex
+	broker  *telemetry.Broker
+	cmds    string
+	dir     string
+	source  string
+	epochs  []telemetry.Telemetry
+	index   int
+	speed   float64
+	loop    bool
+	state   State
+	running bool
+	gen     uint64
+	wake    chan struct{}
+}
+
+// Create a replay controller that publishes recorded epochs to the live telemetry broker, database files are only
+// loaded from dir (empty disables them)
+func NewController(b *telemetry.Broker, sql_fname string, dir string, speed float64, loop bool) *Controller {
+	if speed <= 0 {
+		speed = 1.0
+	}
+	return &Controller{
+		broker: b,
+		cmds:   sql_fname,
+		dir:    dir,
+		speed:  speed,
+		loop:   loop,
+		state:  Stopped,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Load recorded telemetry from a sturdr sqlite database file of the replay directory (relative to it)
+func (c *Controller) LoadDatabase(ctx context.Context, db_file string) error {
+	path, err := c.resolve(db_file)
+	if err != nil {
+		return err
+	}
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabase, err)
+	}
+	defer db.Close()
+
+	// make sure the file was recorded by sturdr before binding statements to it
+	var n int
+	err = db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('navigation', 'satellites')").Scan(&n)
+	if err != nil {
+		return fmt.Errorf("%w: '%s': %v", ErrDatabase, db_file, err)
+	}
+	if n != 2 {
+		return fmt.Errorf("%w: '%s' has no navigation and satellites tables", ErrDatabase, db_file)
+	}
+
+	s, err := telemetry.PrepareTelemetryService(db, c.cmds, nil)
+	if err != nil {
+		return fmt.Errorf("%w: '%s': %v", ErrDatabase, db_file, err)
+	}
+	data, err := s.ReadTelemetry(ctx, 0, 0.0, true, nil)
+	if err != nil {
+		return fmt.Errorf("%w: '%s': %v", ErrDatabase, db_file, err)
+	}
+	return c.Load(db_file, data)
+}
+
+// Existing file of the replay directory named by a path relative to it (or an absolute path inside it)
+func (c *Controller) resolve(db_file string) (string, error) {
+	if c.dir == "" {
+		return "", fmt.Errorf("%w: loading files is disabled (no replay directory)", ErrFile)
+	}
+	dir, err := filepath.Abs(c.dir)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrFile, err)
+	}
+	path := filepath.Clean(db_file)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: '%s' is outside the replay directory", ErrFile, db_file)
+	}
+
+	// links must not lead out of the directory either
+	real_dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrFile, err)
+	}
+	real_path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: '%s' not found", ErrFile, db_file)
+	}
+	if !strings.HasPrefix(real_path, real_dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: '%s' is outside the replay directory", ErrFile, db_file)
+	}
+	return real_path, nil
+}
+
+// Load recorded telemetry, stopping any current playback
+func (c *Controller) Load(source string, data []telemetry.Telemetry) error {
+	if len(data) == 0 {
+		return errors.New("no telemetry epochs to replay")
+	}
+	sort.SliceStable(data, func(i, j int) bool { return epochTime(data[i]) < epochTime(data[j]) })
+
+	c.mu.Lock()
+	c.source = source
+	c.epochs = data
+	c.index = 0
+	c.state = Stopped
+	c.gen++
+	c.mu.Unlock()
+	c.notify()
+
+	logger.Info("Loaded replay epochs ...", "epochs", len(data), "source", source)
+	return nil
+}
+
+// Start or resume playback
+func (c *Controller) Play() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.epochs) == 0 {
+		return errors.New("no replay loaded")
+	}
+	c.state = Playing
+	if !c.running {
+		c.running = true
+		go c.run()
+	}
+	c.notify()
+	return nil
+}
+
+// Pause playback at the current epoch
+func (c *Controller) Pause() {
+	c.mu.Lock()
+	if c.state == Playing {
+		c.state = Paused
+	}
+	c.mu.Unlock()
+	c.notify()
+}
+
+// Stop playback and rewind to the first epoch
+func (c *Controller) Stop() {
+	c.mu.Lock()
+	c.state = Stopped
+	c.index = 0
+	c.gen++
+	c.mu.Unlock()
+	c.notify()
+}
+
+// Seek to the first epoch at or after the requested gps time
+func (c *Controller) Seek(week uint16, tow float32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.epochs) == 0 {
+		return errors.New("no replay loaded")
+	}
+	t := float64(week)*604800.0 + float64(tow)
+	c.index = sort.Search(len(c.epochs), func(i int) bool { return epochTime(c.epochs[i]) >= t })
+	c.gen++
+	c.notify()
+	return nil
+}
+
+// Set the playback speed multiplier
+func (c *Controller) SetSpeed(speed float64) error {
+	if speed <= 0 {
+		return errors.New("replay speed must be positive")
+	}
+	c.mu.Lock()
+	c.speed = speed
+	c.mu.Unlock()
+	c.notify()
+	return nil
+}
+
+// Enable/disable restarting from the first epoch after the last one
+func (c *Controller) SetLoop(loop bool) {
+	c.mu.Lock()
+	c.loop = loop
+	c.mu.Unlock()
+}
+
+// Current replay status
+func (c *Controller) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status := Status{
+		State:  c.state,
+		Source: c.source,
+		Epochs: len(c.epochs),
+		Index:  c.index,
+		Speed:  c.speed,
+		Loop:   c.loop,
+	}
+	if c.index < len(c.epochs) {
+		status.Week = c.epochs[c.index].Navigation.Week
+		status.ToW = c.epochs[c.index].Navigation.ToW
+	}
+	return status
+}
+
+// Playback loop, exits whenever playback is paused or stopped
+func (c *Controller) run() {
+	for {
+		c.mu.Lock()
+		if c.state != Playing {
+			c.running = false
+			c.mu.Unlock()
+			return
+		}
+		if c.index >= len(c.epochs) {
+			if !c.loop {
+				c.state = Stopped
+				c.index = 0
+				c.running = false
+				c.mu.Unlock()
+				logger.Info("Replay finished ...", "source", c.source)
+				return
+			}
+			c.index = 0
+		}
+
+		// 1. grab the current epoch and how long until the next one is due
+		epoch := c.epochs[c.index]
+		c.index++
+		dt := 0.0
+		if c.index < len(c.epochs) {
+			dt = epochTime(c.epochs[c.index]) - epochTime(epoch)
+		} else if c.loop && len(c.epochs) > 1 {
+			dt = epochTime(c.epochs[1]) - epochTime(c.epochs[0])
+		}
+		gen := c.gen
+		c.mu.Unlock()
+
+		// 2. publish to live subscribers
+		c.broker.Publish(epoch)
+
+		// 3. wait for the next epoch
+		c.wait(dt, gen)
+	}
+}
+
+// Sleep for dt seconds of recorded time, returning early if playback is interrupted
+func (c *Controller) wait(dt float64, gen uint64) {
+	for dt > 0 {
+		c.mu.Lock()
+		if c.state != Playing || c.gen != gen {
+			c.mu.Unlock()
+			return
+		}
+		speed := c.speed
+		c.mu.Unlock()
+
+		start := time.Now()
+		timer := time.NewTimer(time.Duration(dt / speed * float64(time.Second)))
+		select {
+		case <-timer.C:
+			return
+		case <-c.wake:
+			// speed changes rescale whatever recorded time is left
+			timer.Stop()
+			dt -= time.Since(start).Seconds() * speed
+		}
+	}
+}
+
+// Interrupt a waiting playback loop
+func (c *Controller) notify() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,126 @@
+package replay
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/logging"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+var logger = logging.For("replay")
+
+type Handler struct {
+	controller *Controller
+}
+
+func NewHttpHandler(c *Controller) *Handler {
+	return &Handler{controller: c}
+}
+
+// Handle http load replay request (a database file of the replay directory or an uploaded json telemetry array)
+func (h *Handler) Load(w http.ResponseWriter, r *http.Request) {
+	if db_file := r.URL.Query().Get("file"); db_file != "" {
+		if err := h.controller.LoadDatabase(r.Context(), db_file); errors.Is(err, ErrDatabase) {
+			handleError(w, r, err, http.StatusUnprocessableEntity)
+			return
+		} else if err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	} else {
+		var data []telemetry.Telemetry
+		if err := encoder.ReadJson(r, &data); err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		if err := h.controller.Load("upload", data); err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	encoder.WriteJson(w, http.StatusOK, h.controller.Status())
+}
+
+// Handle http play/resume replay request
+func (h *Handler) Play(w http.ResponseWriter, r *http.Request) {
+	if err := h.controller.Play(); err != nil {
+		handleError(w, r, err, http.StatusConflict)
+		return
+	}
+	encoder.WriteJson(w, http.StatusOK, h.controller.Status())
+}
+
+// Handle http pause replay request
+func (h *Handler) Pause(w http.ResponseWriter, r *http.Request) {
+	h.controller.Pause()
+	encoder.WriteJson(w, http.StatusOK, h.controller.Status())
+}
+
+// Handle http stop replay request
+func (h *Handler) Stop(w http.ResponseWriter, r *http.Request) {
+	h.controller.Stop()
+	encoder.WriteJson(w, http.StatusOK, h.controller.Status())
+}
+
+// Handle http seek replay request (EX: "/replay/seek?week=2352&tow=507440.0")
+func (h *Handler) Seek(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	week, err := strconv.ParseUint(query.Get("week"), 10, 16)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	tow, err := strconv.ParseFloat(query.Get("tow"), 32)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.controller.Seek(uint16(week), float32(tow)); err != nil {
+		handleError(w, r, err, http.StatusConflict)
+		return
+	}
+	encoder.WriteJson(w, http.StatusOK, h.controller.Status())
+}
+
+// Handle http replay speed request (EX: "/replay/speed?value=2.0")
+func (h *Handler) Speed(w http.ResponseWriter, r *http.Request) {
+	speed, err := strconv.ParseFloat(r.URL.Query().Get("value"), 64)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.controller.SetSpeed(speed); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	encoder.WriteJson(w, http.StatusOK, h.controller.Status())
+}
+
+// Handle http replay loop request (EX: "/replay/loop?enable=true")
+func (h *Handler) Loop(w http.ResponseWriter, r *http.Request) {
+	loop, err := strconv.ParseBool(r.URL.Query().Get("enable"))
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	h.controller.SetLoop(loop)
+	encoder.WriteJson(w, http.StatusOK, h.controller.Status())
+}
+
+// Handle http replay status request
+func (h *Handler) Status(w http.ResponseWriter, r *http.Request) {
+	encoder.WriteJson(w, http.StatusOK, h.controller.Status())
+}
+
+// Reusable error handler
+func handleError(w http.ResponseWriter, r *http.Request, e error, c int) {
+	logger.WarnContext(r.Context(), "Replay error!", "status", c, "error", e)
+	http.Error(w, e.Error(), c)
+}
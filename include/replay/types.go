@@ -0,0 +1,29 @@
+package replay
+
+import "github.com/sturdivant20/sturdr-api/include/telemetry"
+
+// Playback state
+type State string
+
+const (
+	Stopped State = "stopped"
+	Playing State = "playing"
+	Paused  State = "paused"
+)
+
+// Replay status datatype
+type Status struct {
+	State  State   `json:"state"`
+	Source string  `json:"source"`
+	Epochs int     `json:"epochs"`
+	Index  int     `json:"index"`
+	Week   uint16  `json:"week"`
+	ToW    float32 `json:"tow"`
+	Speed  float64 `json:"speed"`
+	Loop   bool    `json:"loop"`
+}
+
+// GPS time of an epoch in seconds
+func epochTime(data telemetry.Telemetry) float64 {
+	return float64(data.Navigation.Week)*604800.0 + float64(data.Navigation.ToW)
+}
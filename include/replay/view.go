@@ -0,0 +1,173 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// Replay views only read
+var ErrReadOnly = errors.New("replayed telemetry is read-only")
+
+// Epochs of the recording up to the playback position (what has been published, or skipped by a seek)
+func (c *Controller) played() []telemetry.Telemetry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.epochs[:c.index] // loaded epochs are never modified, a new load replaces the slice
+}
+
+// Version of the played epochs, changes whenever a read of them could (EX: "3-1520")
+func (c *Controller) Version() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fmt.Sprintf("%d-%d", c.gen, c.index)
+}
+
+// Latest played epoch, or the played epochs at or after the requested gps time
+func (c *Controller) read(week uint16, tow float32, do_query bool) []telemetry.Telemetry {
+	played := c.played()
+	if !do_query {
+		if len(played) == 0 {
+			return nil
+		}
+		return played[len(played)-1:]
+	}
+	t := float64(week)*604800.0 + float64(tow)
+	for i := range played {
+		if epochTime(played[i]) >= t {
+			return played[i:]
+		}
+	}
+	return nil
+}
+
+// --- telemetry ---
+
+type telemetryView struct {
+	c *Controller
+}
+
+// Read-only telemetry service over the played epochs (the read endpoints serve it with "source=replay")
+func (c *Controller) TelemetryView() telemetry.Service {
+	return &telemetryView{c: c}
+}
+
+func (v *telemetryView) CreateTelemetry(ctx context.Context, data telemetry.Telemetry) error {
+	return ErrReadOnly
+}
+
+func (v *telemetryView) CreateTelemetryBatch(ctx context.Context, data []telemetry.Telemetry) error {
+	return ErrReadOnly
+}
+
+// Copies of the epochs with every field, the handlers project the selected ones
+func (v *telemetryView) ReadTelemetry(
+	ctx context.Context, week uint16, tow float32, do_query bool, fields *encoder.Fields) ([]telemetry.Telemetry, error) {
+
+	played := v.c.read(week, tow, do_query)
+	items := make([]telemetry.Telemetry, len(played))
+	for i := range played {
+		items[i].Navigation = played[i].Navigation
+		items[i].Satellites = append([]satellite.Satellite(nil), played[i].Satellites...)
+	}
+	return items, nil
+}
+
+func (v *telemetryView) UpdateTelemetry(ctx context.Context, data telemetry.Telemetry, sequence int64) error {
+	return ErrReadOnly
+}
+
+func (v *telemetryView) DeleteTelemetry(ctx context.Context, sequence int64) error {
+	return ErrReadOnly
+}
+
+// --- navigation ---
+
+type navigationView struct {
+	c *Controller
+}
+
+// Read-only navigation service over the played epochs
+func (c *Controller) NavigationView() navigation.Service {
+	return &navigationView{c: c}
+}
+
+func (v *navigationView) CreateNavigation(ctx context.Context, n navigation.Navigation) error {
+	return ErrReadOnly
+}
+
+func (v *navigationView) ReadNavigation(
+	ctx context.Context, week uint16, tow float32, do_query bool, fields *encoder.Fields) ([]navigation.Navigation, error) {
+
+	played := v.c.read(week, tow, do_query)
+	items := make([]navigation.Navigation, len(played))
+	for i := range played {
+		items[i] = played[i].Navigation
+	}
+	return items, nil
+}
+
+func (v *navigationView) UpdateNavigation(ctx context.Context, n navigation.Navigation, id int64) error {
+	return ErrReadOnly
+}
+
+func (v *navigationView) DeleteNavigation(ctx context.Context, id int64) error {
+	return ErrReadOnly
+}
+
+// --- satellite ---
+
+type satelliteView struct {
+	c *Controller
+}
+
+// Read-only satellite service over the played epochs
+func (c *Controller) SatelliteView() satellite.Service {
+	return &satelliteView{c: c}
+}
+
+func (v *satelliteView) CreateSatellite(ctx context.Context, sv satellite.Satellite) error {
+	return ErrReadOnly
+}
+
+// Satellites of the played epochs in time order, of one prn unless it is 255
+func (v *satelliteView) ReadSatellite(ctx context.Context,
+	week uint16, tow float32, prn uint8, do_query bool, fields *encoder.Fields) ([]satellite.Satellite, error) {
+
+	// the latest record of one prn comes from the last epoch that tracked it
+	if !do_query && prn != 255 {
+		played := v.c.played()
+		for i := len(played) - 1; i >= 0; i-- {
+			for _, sv := range played[i].Satellites {
+				if sv.PRN == prn {
+					return []satellite.Satellite{sv}, nil
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	played := v.c.read(week, tow, do_query)
+	var items []satellite.Satellite
+	for i := range played {
+		for _, sv := range played[i].Satellites {
+			if prn == 255 || sv.PRN == prn {
+				items = append(items, sv)
+			}
+		}
+	}
+	return items, nil
+}
+
+func (v *satelliteView) UpdateSatellite(ctx context.Context, sv satellite.Satellite, id int64) error {
+	return ErrReadOnly
+}
+
+func (v *satelliteView) DeleteSatellite(ctx context.Context, id int64) error {
+	return ErrReadOnly
+}
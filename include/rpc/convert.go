@@ -0,0 +1,169 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/rpc/pb"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// --- navigation ---
+
+func fromNavigation(n *navigation.Navigation) *pb.Navigation {
+	return &pb.Navigation{
+		Sequence:  n.Sequence,
+		Week:      uint32(n.Week),
+		Tow:       n.ToW,
+		NSat:      uint32(n.NSat),
+		Latitude:  n.Latitude,
+		Longitude: n.Longitude,
+		Altitude:  n.Altitude,
+		Vn:        n.Vn,
+		Ve:        n.Ve,
+		Vd:        n.Vd,
+		Roll:      n.Roll,
+		Pitch:     n.Pitch,
+		Yaw:       n.Yaw,
+		Pdop:      n.PDOP,
+		Hdop:      n.HDOP,
+		Vdop:      n.VDOP,
+	}
+}
+
+func toNavigation(m *pb.Navigation) (navigation.Navigation, error) {
+	if m == nil {
+		return navigation.Navigation{}, fmt.Errorf("missing navigation")
+	}
+	if m.Week > 0xFFFF || m.NSat > 0xFF {
+		return navigation.Navigation{}, fmt.Errorf("navigation week %d or n_sat %d out of range", m.Week, m.NSat)
+	}
+	return navigation.Navigation{
+		Sequence:  m.Sequence,
+		Week:      uint16(m.Week),
+		ToW:       m.Tow,
+		NSat:      uint8(m.NSat),
+		Latitude:  m.Latitude,
+		Longitude: m.Longitude,
+		Altitude:  m.Altitude,
+		Vn:        m.Vn,
+		Ve:        m.Ve,
+		Vd:        m.Vd,
+		Roll:      m.Roll,
+		Pitch:     m.Pitch,
+		Yaw:       m.Yaw,
+		PDOP:      m.Pdop,
+		HDOP:      m.Hdop,
+		VDOP:      m.Vdop,
+	}, nil
+}
+
+// --- satellite ---
+
+func fromSatellite(sv *satellite.Satellite) *pb.Satellite {
+	return &pb.Satellite{
+		Sequence:  sv.Sequence,
+		Week:      uint32(sv.Week),
+		Tow:       sv.ToW,
+		Prn:       uint32(sv.PRN),
+		Health:    uint32(sv.Health),
+		X:         sv.X,
+		Y:         sv.Y,
+		Z:         sv.Z,
+		Vx:        sv.Vx,
+		Vy:        sv.Vy,
+		Vz:        sv.Vz,
+		Doppler:   sv.Doppler,
+		Psr:       sv.PSR,
+		Adr:       sv.ADR,
+		Azimuth:   sv.Azimuth,
+		Elevation: sv.Elevation,
+		Cno:       sv.CNo,
+		Ie:        sv.IE,
+		Ip:        sv.IP,
+		Il:        sv.IL,
+		Qe:        sv.QE,
+		Qp:        sv.QP,
+		Ql:        sv.QL,
+	}
+}
+
+func toSatellite(m *pb.Satellite) (satellite.Satellite, error) {
+	if m == nil {
+		return satellite.Satellite{}, fmt.Errorf("missing satellite")
+	}
+	if m.Week > 0xFFFF || m.Prn > 0xFF || m.Health > 0xFF {
+		return satellite.Satellite{}, fmt.Errorf("satellite week %d, prn %d or health %d out of range", m.Week, m.Prn, m.Health)
+	}
+	return satellite.Satellite{
+		Sequence:  m.Sequence,
+		Week:      uint16(m.Week),
+		ToW:       m.Tow,
+		PRN:       uint8(m.Prn),
+		Health:    uint8(m.Health),
+		X:         m.X,
+		Y:         m.Y,
+		Z:         m.Z,
+		Vx:        m.Vx,
+		Vy:        m.Vy,
+		Vz:        m.Vz,
+		Doppler:   m.Doppler,
+		PSR:       m.Psr,
+		ADR:       m.Adr,
+		Azimuth:   m.Azimuth,
+		Elevation: m.Elevation,
+		CNo:       m.Cno,
+		IE:        m.Ie,
+		IP:        m.Ip,
+		IL:        m.Il,
+		QE:        m.Qe,
+		QP:        m.Qp,
+		QL:        m.Ql,
+	}, nil
+}
+
+// --- telemetry ---
+
+func fromTelemetry(data *telemetry.Telemetry) *pb.Telemetry {
+	m := &pb.Telemetry{
+		Navigation: fromNavigation(&data.Navigation),
+		Satellites: make([]*pb.Satellite, len(data.Satellites)),
+	}
+	for i := range data.Satellites {
+		m.Satellites[i] = fromSatellite(&data.Satellites[i])
+	}
+	return m
+}
+
+func toTelemetry(m *pb.Telemetry) (telemetry.Telemetry, error) {
+	if m == nil {
+		return telemetry.Telemetry{}, fmt.Errorf("missing telemetry")
+	}
+	n, err := toNavigation(m.Navigation)
+	if err != nil {
+		return telemetry.Telemetry{}, err
+	}
+	data := telemetry.Telemetry{Navigation: n, Satellites: make([]satellite.Satellite, len(m.Satellites))}
+	for i, sv := range m.Satellites {
+		if data.Satellites[i], err = toSatellite(sv); err != nil {
+			return telemetry.Telemetry{}, err
+		}
+	}
+	return data, nil
+}
+
+// Read range of a request (255 selects every prn, as in the http handlers)
+func readRange(req *pb.ReadRequest) (uint16, float32, uint8, bool, error) {
+	prn := uint8(255)
+	if req.Prn != nil {
+		if *req.Prn > 0xFF {
+			return 0, 0, 0, false, fmt.Errorf("prn %d out of range", *req.Prn)
+		}
+		prn = uint8(*req.Prn)
+	}
+	if req.Week > 0xFFFF {
+		return 0, 0, 0, false, fmt.Errorf("week %d out of range", req.Week)
+	}
+	return uint16(req.Week), req.Tow, prn, req.Since, nil
+}
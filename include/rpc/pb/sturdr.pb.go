@@ -0,0 +1,1143 @@
+// Sturdr receiver telemetry (field names match the json keys of the REST-API)
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: sturdr/v1/sturdr.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Navigation solution of one epoch
+type Navigation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Week          uint32                 `protobuf:"varint,2,opt,name=week,proto3" json:"week,omitempty"`             // gps week
+	Tow           float32                `protobuf:"fixed32,3,opt,name=tow,proto3" json:"tow,omitempty"`              // gps time of week [s]
+	NSat          uint32                 `protobuf:"varint,4,opt,name=n_sat,json=nSat,proto3" json:"n_sat,omitempty"` // satellites used
+	Latitude      float32                `protobuf:"fixed32,5,opt,name=latitude,proto3" json:"latitude,omitempty"`    // [deg]
+	Longitude     float32                `protobuf:"fixed32,6,opt,name=longitude,proto3" json:"longitude,omitempty"`  // [deg]
+	Altitude      float32                `protobuf:"fixed32,7,opt,name=altitude,proto3" json:"altitude,omitempty"`    // [m]
+	Vn            float32                `protobuf:"fixed32,8,opt,name=vn,proto3" json:"vn,omitempty"`                // north velocity [m/s]
+	Ve            float32                `protobuf:"fixed32,9,opt,name=ve,proto3" json:"ve,omitempty"`                // east velocity [m/s]
+	Vd            float32                `protobuf:"fixed32,10,opt,name=vd,proto3" json:"vd,omitempty"`               // down velocity [m/s]
+	Roll          float32                `protobuf:"fixed32,11,opt,name=roll,proto3" json:"roll,omitempty"`           // [deg]
+	Pitch         float32                `protobuf:"fixed32,12,opt,name=pitch,proto3" json:"pitch,omitempty"`         // [deg]
+	Yaw           float32                `protobuf:"fixed32,13,opt,name=yaw,proto3" json:"yaw,omitempty"`             // [deg]
+	Pdop          float32                `protobuf:"fixed32,14,opt,name=pdop,proto3" json:"pdop,omitempty"`
+	Hdop          float32                `protobuf:"fixed32,15,opt,name=hdop,proto3" json:"hdop,omitempty"`
+	Vdop          float32                `protobuf:"fixed32,16,opt,name=vdop,proto3" json:"vdop,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Navigation) Reset() {
+	*x = Navigation{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Navigation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Navigation) ProtoMessage() {}
+
+func (x *Navigation) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Navigation.ProtoReflect.Descriptor instead.
+func (*Navigation) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Navigation) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Navigation) GetWeek() uint32 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *Navigation) GetTow() float32 {
+	if x != nil {
+		return x.Tow
+	}
+	return 0
+}
+
+func (x *Navigation) GetNSat() uint32 {
+	if x != nil {
+		return x.NSat
+	}
+	return 0
+}
+
+func (x *Navigation) GetLatitude() float32 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Navigation) GetLongitude() float32 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Navigation) GetAltitude() float32 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+func (x *Navigation) GetVn() float32 {
+	if x != nil {
+		return x.Vn
+	}
+	return 0
+}
+
+func (x *Navigation) GetVe() float32 {
+	if x != nil {
+		return x.Ve
+	}
+	return 0
+}
+
+func (x *Navigation) GetVd() float32 {
+	if x != nil {
+		return x.Vd
+	}
+	return 0
+}
+
+func (x *Navigation) GetRoll() float32 {
+	if x != nil {
+		return x.Roll
+	}
+	return 0
+}
+
+func (x *Navigation) GetPitch() float32 {
+	if x != nil {
+		return x.Pitch
+	}
+	return 0
+}
+
+func (x *Navigation) GetYaw() float32 {
+	if x != nil {
+		return x.Yaw
+	}
+	return 0
+}
+
+func (x *Navigation) GetPdop() float32 {
+	if x != nil {
+		return x.Pdop
+	}
+	return 0
+}
+
+func (x *Navigation) GetHdop() float32 {
+	if x != nil {
+		return x.Hdop
+	}
+	return 0
+}
+
+func (x *Navigation) GetVdop() float32 {
+	if x != nil {
+		return x.Vdop
+	}
+	return 0
+}
+
+// Tracking state of one satellite in one epoch
+type Satellite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"` // navigation epoch
+	Week          uint32                 `protobuf:"varint,2,opt,name=week,proto3" json:"week,omitempty"`
+	Tow           float32                `protobuf:"fixed32,3,opt,name=tow,proto3" json:"tow,omitempty"`
+	Prn           uint32                 `protobuf:"varint,4,opt,name=prn,proto3" json:"prn,omitempty"` // 0-31 gps, 32-61 galileo, 64-95 glonass, 96-158 beidou, 159-163 qzss, 164-170 navic
+	Health        uint32                 `protobuf:"varint,5,opt,name=health,proto3" json:"health,omitempty"`
+	X             float32                `protobuf:"fixed32,6,opt,name=x,proto3" json:"x,omitempty"` // ecef position [m]
+	Y             float32                `protobuf:"fixed32,7,opt,name=y,proto3" json:"y,omitempty"`
+	Z             float32                `protobuf:"fixed32,8,opt,name=z,proto3" json:"z,omitempty"`
+	Vx            float32                `protobuf:"fixed32,9,opt,name=vx,proto3" json:"vx,omitempty"` // ecef velocity [m/s]
+	Vy            float32                `protobuf:"fixed32,10,opt,name=vy,proto3" json:"vy,omitempty"`
+	Vz            float32                `protobuf:"fixed32,11,opt,name=vz,proto3" json:"vz,omitempty"`
+	Doppler       float32                `protobuf:"fixed32,12,opt,name=doppler,proto3" json:"doppler,omitempty"`     // [hz]
+	Psr           float32                `protobuf:"fixed32,13,opt,name=psr,proto3" json:"psr,omitempty"`             // pseudorange [m]
+	Adr           float32                `protobuf:"fixed32,14,opt,name=adr,proto3" json:"adr,omitempty"`             // accumulated delta range [cycles]
+	Azimuth       float32                `protobuf:"fixed32,15,opt,name=azimuth,proto3" json:"azimuth,omitempty"`     // [deg]
+	Elevation     float32                `protobuf:"fixed32,16,opt,name=elevation,proto3" json:"elevation,omitempty"` // [deg]
+	Cno           float32                `protobuf:"fixed32,17,opt,name=cno,proto3" json:"cno,omitempty"`             // [db-hz]
+	Ie            float32                `protobuf:"fixed32,18,opt,name=ie,proto3" json:"ie,omitempty"`               // early, prompt and late correlators
+	Ip            float32                `protobuf:"fixed32,19,opt,name=ip,proto3" json:"ip,omitempty"`
+	Il            float32                `protobuf:"fixed32,20,opt,name=il,proto3" json:"il,omitempty"`
+	Qe            float32                `protobuf:"fixed32,21,opt,name=qe,proto3" json:"qe,omitempty"`
+	Qp            float32                `protobuf:"fixed32,22,opt,name=qp,proto3" json:"qp,omitempty"`
+	Ql            float32                `protobuf:"fixed32,23,opt,name=ql,proto3" json:"ql,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Satellite) Reset() {
+	*x = Satellite{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Satellite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Satellite) ProtoMessage() {}
+
+func (x *Satellite) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Satellite.ProtoReflect.Descriptor instead.
+func (*Satellite) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Satellite) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Satellite) GetWeek() uint32 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *Satellite) GetTow() float32 {
+	if x != nil {
+		return x.Tow
+	}
+	return 0
+}
+
+func (x *Satellite) GetPrn() uint32 {
+	if x != nil {
+		return x.Prn
+	}
+	return 0
+}
+
+func (x *Satellite) GetHealth() uint32 {
+	if x != nil {
+		return x.Health
+	}
+	return 0
+}
+
+func (x *Satellite) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Satellite) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Satellite) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *Satellite) GetVx() float32 {
+	if x != nil {
+		return x.Vx
+	}
+	return 0
+}
+
+func (x *Satellite) GetVy() float32 {
+	if x != nil {
+		return x.Vy
+	}
+	return 0
+}
+
+func (x *Satellite) GetVz() float32 {
+	if x != nil {
+		return x.Vz
+	}
+	return 0
+}
+
+func (x *Satellite) GetDoppler() float32 {
+	if x != nil {
+		return x.Doppler
+	}
+	return 0
+}
+
+func (x *Satellite) GetPsr() float32 {
+	if x != nil {
+		return x.Psr
+	}
+	return 0
+}
+
+func (x *Satellite) GetAdr() float32 {
+	if x != nil {
+		return x.Adr
+	}
+	return 0
+}
+
+func (x *Satellite) GetAzimuth() float32 {
+	if x != nil {
+		return x.Azimuth
+	}
+	return 0
+}
+
+func (x *Satellite) GetElevation() float32 {
+	if x != nil {
+		return x.Elevation
+	}
+	return 0
+}
+
+func (x *Satellite) GetCno() float32 {
+	if x != nil {
+		return x.Cno
+	}
+	return 0
+}
+
+func (x *Satellite) GetIe() float32 {
+	if x != nil {
+		return x.Ie
+	}
+	return 0
+}
+
+func (x *Satellite) GetIp() float32 {
+	if x != nil {
+		return x.Ip
+	}
+	return 0
+}
+
+func (x *Satellite) GetIl() float32 {
+	if x != nil {
+		return x.Il
+	}
+	return 0
+}
+
+func (x *Satellite) GetQe() float32 {
+	if x != nil {
+		return x.Qe
+	}
+	return 0
+}
+
+func (x *Satellite) GetQp() float32 {
+	if x != nil {
+		return x.Qp
+	}
+	return 0
+}
+
+func (x *Satellite) GetQl() float32 {
+	if x != nil {
+		return x.Ql
+	}
+	return 0
+}
+
+// Navigation solution and the satellites of one epoch
+type Telemetry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Navigation    *Navigation            `protobuf:"bytes,1,opt,name=navigation,proto3" json:"navigation,omitempty"`
+	Satellites    []*Satellite           `protobuf:"bytes,2,rep,name=satellites,proto3" json:"satellites,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Telemetry) Reset() {
+	*x = Telemetry{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Telemetry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Telemetry) ProtoMessage() {}
+
+func (x *Telemetry) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Telemetry.ProtoReflect.Descriptor instead.
+func (*Telemetry) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Telemetry) GetNavigation() *Navigation {
+	if x != nil {
+		return x.Navigation
+	}
+	return nil
+}
+
+func (x *Telemetry) GetSatellites() []*Satellite {
+	if x != nil {
+		return x.Satellites
+	}
+	return nil
+}
+
+// Read the latest epoch, or every epoch at or after week/tow when since is set
+type ReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         bool                   `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	Week          uint32                 `protobuf:"varint,2,opt,name=week,proto3" json:"week,omitempty"`
+	Tow           float32                `protobuf:"fixed32,3,opt,name=tow,proto3" json:"tow,omitempty"`
+	Prn           *uint32                `protobuf:"varint,4,opt,name=prn,proto3,oneof" json:"prn,omitempty"` // satellite reads only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadRequest) Reset() {
+	*x = ReadRequest{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRequest) ProtoMessage() {}
+
+func (x *ReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRequest.ProtoReflect.Descriptor instead.
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReadRequest) GetSince() bool {
+	if x != nil {
+		return x.Since
+	}
+	return false
+}
+
+func (x *ReadRequest) GetWeek() uint32 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *ReadRequest) GetTow() float32 {
+	if x != nil {
+		return x.Tow
+	}
+	return 0
+}
+
+func (x *ReadRequest) GetPrn() uint32 {
+	if x != nil && x.Prn != nil {
+		return *x.Prn
+	}
+	return 0
+}
+
+type NavigationList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Navigation          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NavigationList) Reset() {
+	*x = NavigationList{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NavigationList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NavigationList) ProtoMessage() {}
+
+func (x *NavigationList) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NavigationList.ProtoReflect.Descriptor instead.
+func (*NavigationList) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NavigationList) GetItems() []*Navigation {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type SatelliteList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Satellite           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SatelliteList) Reset() {
+	*x = SatelliteList{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SatelliteList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SatelliteList) ProtoMessage() {}
+
+func (x *SatelliteList) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SatelliteList.ProtoReflect.Descriptor instead.
+func (*SatelliteList) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SatelliteList) GetItems() []*Satellite {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type TelemetryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Telemetry           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TelemetryList) Reset() {
+	*x = TelemetryList{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryList) ProtoMessage() {}
+
+func (x *TelemetryList) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryList.ProtoReflect.Descriptor instead.
+func (*TelemetryList) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TelemetryList) GetItems() []*Telemetry {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type UpdateNavigationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Navigation    *Navigation            `protobuf:"bytes,2,opt,name=navigation,proto3" json:"navigation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNavigationRequest) Reset() {
+	*x = UpdateNavigationRequest{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNavigationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNavigationRequest) ProtoMessage() {}
+
+func (x *UpdateNavigationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNavigationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNavigationRequest) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateNavigationRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateNavigationRequest) GetNavigation() *Navigation {
+	if x != nil {
+		return x.Navigation
+	}
+	return nil
+}
+
+type UpdateSatelliteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Satellite     *Satellite             `protobuf:"bytes,2,opt,name=satellite,proto3" json:"satellite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSatelliteRequest) Reset() {
+	*x = UpdateSatelliteRequest{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSatelliteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSatelliteRequest) ProtoMessage() {}
+
+func (x *UpdateSatelliteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSatelliteRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSatelliteRequest) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateSatelliteRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateSatelliteRequest) GetSatellite() *Satellite {
+	if x != nil {
+		return x.Satellite
+	}
+	return nil
+}
+
+type UpdateTelemetryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Telemetry     *Telemetry             `protobuf:"bytes,2,opt,name=telemetry,proto3" json:"telemetry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTelemetryRequest) Reset() {
+	*x = UpdateTelemetryRequest{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTelemetryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTelemetryRequest) ProtoMessage() {}
+
+func (x *UpdateTelemetryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTelemetryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTelemetryRequest) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateTelemetryRequest) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *UpdateTelemetryRequest) GetTelemetry() *Telemetry {
+	if x != nil {
+		return x.Telemetry
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // row id (sequence for telemetry)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{11}
+}
+
+type IngestSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epochs        uint64                 `protobuf:"varint,1,opt,name=epochs,proto3" json:"epochs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestSummary) Reset() {
+	*x = IngestSummary{}
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestSummary) ProtoMessage() {}
+
+func (x *IngestSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_sturdr_v1_sturdr_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestSummary.ProtoReflect.Descriptor instead.
+func (*IngestSummary) Descriptor() ([]byte, []int) {
+	return file_sturdr_v1_sturdr_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IngestSummary) GetEpochs() uint64 {
+	if x != nil {
+		return x.Epochs
+	}
+	return 0
+}
+
+var File_sturdr_v1_sturdr_proto protoreflect.FileDescriptor
+
+const file_sturdr_v1_sturdr_proto_rawDesc = "" +
+	"\n" +
+	"\x16sturdr/v1/sturdr.proto\x12\tsturdr.v1\x1a\x1bgoogle/protobuf/empty.proto\"\xe1\x02\n" +
+	"\n" +
+	"Navigation\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x12\n" +
+	"\x04week\x18\x02 \x01(\rR\x04week\x12\x10\n" +
+	"\x03tow\x18\x03 \x01(\x02R\x03tow\x12\x13\n" +
+	"\x05n_sat\x18\x04 \x01(\rR\x04nSat\x12\x1a\n" +
+	"\blatitude\x18\x05 \x01(\x02R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x06 \x01(\x02R\tlongitude\x12\x1a\n" +
+	"\baltitude\x18\a \x01(\x02R\baltitude\x12\x0e\n" +
+	"\x02vn\x18\b \x01(\x02R\x02vn\x12\x0e\n" +
+	"\x02ve\x18\t \x01(\x02R\x02ve\x12\x0e\n" +
+	"\x02vd\x18\n" +
+	" \x01(\x02R\x02vd\x12\x12\n" +
+	"\x04roll\x18\v \x01(\x02R\x04roll\x12\x14\n" +
+	"\x05pitch\x18\f \x01(\x02R\x05pitch\x12\x10\n" +
+	"\x03yaw\x18\r \x01(\x02R\x03yaw\x12\x12\n" +
+	"\x04pdop\x18\x0e \x01(\x02R\x04pdop\x12\x12\n" +
+	"\x04hdop\x18\x0f \x01(\x02R\x04hdop\x12\x12\n" +
+	"\x04vdop\x18\x10 \x01(\x02R\x04vdop\"\xb9\x03\n" +
+	"\tSatellite\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x12\n" +
+	"\x04week\x18\x02 \x01(\rR\x04week\x12\x10\n" +
+	"\x03tow\x18\x03 \x01(\x02R\x03tow\x12\x10\n" +
+	"\x03prn\x18\x04 \x01(\rR\x03prn\x12\x16\n" +
+	"\x06health\x18\x05 \x01(\rR\x06health\x12\f\n" +
+	"\x01x\x18\x06 \x01(\x02R\x01x\x12\f\n" +
+	"\x01y\x18\a \x01(\x02R\x01y\x12\f\n" +
+	"\x01z\x18\b \x01(\x02R\x01z\x12\x0e\n" +
+	"\x02vx\x18\t \x01(\x02R\x02vx\x12\x0e\n" +
+	"\x02vy\x18\n" +
+	" \x01(\x02R\x02vy\x12\x0e\n" +
+	"\x02vz\x18\v \x01(\x02R\x02vz\x12\x18\n" +
+	"\adoppler\x18\f \x01(\x02R\adoppler\x12\x10\n" +
+	"\x03psr\x18\r \x01(\x02R\x03psr\x12\x10\n" +
+	"\x03adr\x18\x0e \x01(\x02R\x03adr\x12\x18\n" +
+	"\aazimuth\x18\x0f \x01(\x02R\aazimuth\x12\x1c\n" +
+	"\televation\x18\x10 \x01(\x02R\televation\x12\x10\n" +
+	"\x03cno\x18\x11 \x01(\x02R\x03cno\x12\x0e\n" +
+	"\x02ie\x18\x12 \x01(\x02R\x02ie\x12\x0e\n" +
+	"\x02ip\x18\x13 \x01(\x02R\x02ip\x12\x0e\n" +
+	"\x02il\x18\x14 \x01(\x02R\x02il\x12\x0e\n" +
+	"\x02qe\x18\x15 \x01(\x02R\x02qe\x12\x0e\n" +
+	"\x02qp\x18\x16 \x01(\x02R\x02qp\x12\x0e\n" +
+	"\x02ql\x18\x17 \x01(\x02R\x02ql\"x\n" +
+	"\tTelemetry\x125\n" +
+	"\n" +
+	"navigation\x18\x01 \x01(\v2\x15.sturdr.v1.NavigationR\n" +
+	"navigation\x124\n" +
+	"\n" +
+	"satellites\x18\x02 \x03(\v2\x14.sturdr.v1.SatelliteR\n" +
+	"satellites\"h\n" +
+	"\vReadRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\bR\x05since\x12\x12\n" +
+	"\x04week\x18\x02 \x01(\rR\x04week\x12\x10\n" +
+	"\x03tow\x18\x03 \x01(\x02R\x03tow\x12\x15\n" +
+	"\x03prn\x18\x04 \x01(\rH\x00R\x03prn\x88\x01\x01B\x06\n" +
+	"\x04_prn\"=\n" +
+	"\x0eNavigationList\x12+\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.sturdr.v1.NavigationR\x05items\";\n" +
+	"\rSatelliteList\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.sturdr.v1.SatelliteR\x05items\";\n" +
+	"\rTelemetryList\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.sturdr.v1.TelemetryR\x05items\"`\n" +
+	"\x17UpdateNavigationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x125\n" +
+	"\n" +
+	"navigation\x18\x02 \x01(\v2\x15.sturdr.v1.NavigationR\n" +
+	"navigation\"\\\n" +
+	"\x16UpdateSatelliteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x122\n" +
+	"\tsatellite\x18\x02 \x01(\v2\x14.sturdr.v1.SatelliteR\tsatellite\"h\n" +
+	"\x16UpdateTelemetryRequest\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x122\n" +
+	"\ttelemetry\x18\x02 \x01(\v2\x14.sturdr.v1.TelemetryR\ttelemetry\"\x1f\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x12\n" +
+	"\x10SubscribeRequest\"'\n" +
+	"\rIngestSummary\x12\x16\n" +
+	"\x06epochs\x18\x01 \x01(\x04R\x06epochs2\xd9\a\n" +
+	"\rSturdrService\x12A\n" +
+	"\x10CreateNavigation\x12\x15.sturdr.v1.Navigation\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\x0eReadNavigation\x12\x16.sturdr.v1.ReadRequest\x1a\x19.sturdr.v1.NavigationList\x12N\n" +
+	"\x10UpdateNavigation\x12\".sturdr.v1.UpdateNavigationRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\x10DeleteNavigation\x12\x18.sturdr.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\x0fCreateSatellite\x12\x14.sturdr.v1.Satellite\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\rReadSatellite\x12\x16.sturdr.v1.ReadRequest\x1a\x18.sturdr.v1.SatelliteList\x12L\n" +
+	"\x0fUpdateSatellite\x12!.sturdr.v1.UpdateSatelliteRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\x0fDeleteSatellite\x12\x18.sturdr.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\x0fCreateTelemetry\x12\x14.sturdr.v1.Telemetry\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\rReadTelemetry\x12\x16.sturdr.v1.ReadRequest\x1a\x18.sturdr.v1.TelemetryList\x12L\n" +
+	"\x0fUpdateTelemetry\x12!.sturdr.v1.UpdateTelemetryRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\x0fDeleteTelemetry\x12\x18.sturdr.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\tSubscribe\x12\x1b.sturdr.v1.SubscribeRequest\x1a\x14.sturdr.v1.Telemetry0\x01\x12:\n" +
+	"\x06Ingest\x12\x14.sturdr.v1.Telemetry\x1a\x18.sturdr.v1.IngestSummary(\x01B6Z4github.com/sturdivant20/sturdr-api/include/rpc/pb;pbb\x06proto3"
+
+var (
+	file_sturdr_v1_sturdr_proto_rawDescOnce sync.Once
+	file_sturdr_v1_sturdr_proto_rawDescData []byte
+)
+
+func file_sturdr_v1_sturdr_proto_rawDescGZIP() []byte {
+	file_sturdr_v1_sturdr_proto_rawDescOnce.Do(func() {
+		file_sturdr_v1_sturdr_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sturdr_v1_sturdr_proto_rawDesc), len(file_sturdr_v1_sturdr_proto_rawDesc)))
+	})
+	return file_sturdr_v1_sturdr_proto_rawDescData
+}
+
+var file_sturdr_v1_sturdr_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_sturdr_v1_sturdr_proto_goTypes = []any{
+	(*Navigation)(nil),              // 0: sturdr.v1.Navigation
+	(*Satellite)(nil),               // 1: sturdr.v1.Satellite
+	(*Telemetry)(nil),               // 2: sturdr.v1.Telemetry
+	(*ReadRequest)(nil),             // 3: sturdr.v1.ReadRequest
+	(*NavigationList)(nil),          // 4: sturdr.v1.NavigationList
+	(*SatelliteList)(nil),           // 5: sturdr.v1.SatelliteList
+	(*TelemetryList)(nil),           // 6: sturdr.v1.TelemetryList
+	(*UpdateNavigationRequest)(nil), // 7: sturdr.v1.UpdateNavigationRequest
+	(*UpdateSatelliteRequest)(nil),  // 8: sturdr.v1.UpdateSatelliteRequest
+	(*UpdateTelemetryRequest)(nil),  // 9: sturdr.v1.UpdateTelemetryRequest
+	(*DeleteRequest)(nil),           // 10: sturdr.v1.DeleteRequest
+	(*SubscribeRequest)(nil),        // 11: sturdr.v1.SubscribeRequest
+	(*IngestSummary)(nil),           // 12: sturdr.v1.IngestSummary
+	(*emptypb.Empty)(nil),           // 13: google.protobuf.Empty
+}
+var file_sturdr_v1_sturdr_proto_depIdxs = []int32{
+	0,  // 0: sturdr.v1.Telemetry.navigation:type_name -> sturdr.v1.Navigation
+	1,  // 1: sturdr.v1.Telemetry.satellites:type_name -> sturdr.v1.Satellite
+	0,  // 2: sturdr.v1.NavigationList.items:type_name -> sturdr.v1.Navigation
+	1,  // 3: sturdr.v1.SatelliteList.items:type_name -> sturdr.v1.Satellite
+	2,  // 4: sturdr.v1.TelemetryList.items:type_name -> sturdr.v1.Telemetry
+	0,  // 5: sturdr.v1.UpdateNavigationRequest.navigation:type_name -> sturdr.v1.Navigation
+	1,  // 6: sturdr.v1.UpdateSatelliteRequest.satellite:type_name -> sturdr.v1.Satellite
+	2,  // 7: sturdr.v1.UpdateTelemetryRequest.telemetry:type_name -> sturdr.v1.Telemetry
+	0,  // 8: sturdr.v1.SturdrService.CreateNavigation:input_type -> sturdr.v1.Navigation
+	3,  // 9: sturdr.v1.SturdrService.ReadNavigation:input_type -> sturdr.v1.ReadRequest
+	7,  // 10: sturdr.v1.SturdrService.UpdateNavigation:input_type -> sturdr.v1.UpdateNavigationRequest
+	10, // 11: sturdr.v1.SturdrService.DeleteNavigation:input_type -> sturdr.v1.DeleteRequest
+	1,  // 12: sturdr.v1.SturdrService.CreateSatellite:input_type -> sturdr.v1.Satellite
+	3,  // 13: sturdr.v1.SturdrService.ReadSatellite:input_type -> sturdr.v1.ReadRequest
+	8,  // 14: sturdr.v1.SturdrService.UpdateSatellite:input_type -> sturdr.v1.UpdateSatelliteRequest
+	10, // 15: sturdr.v1.SturdrService.DeleteSatellite:input_type -> sturdr.v1.DeleteRequest
+	2,  // 16: sturdr.v1.SturdrService.CreateTelemetry:input_type -> sturdr.v1.Telemetry
+	3,  // 17: sturdr.v1.SturdrService.ReadTelemetry:input_type -> sturdr.v1.ReadRequest
+	9,  // 18: sturdr.v1.SturdrService.UpdateTelemetry:input_type -> sturdr.v1.UpdateTelemetryRequest
+	10, // 19: sturdr.v1.SturdrService.DeleteTelemetry:input_type -> sturdr.v1.DeleteRequest
+	11, // 20: sturdr.v1.SturdrService.Subscribe:input_type -> sturdr.v1.SubscribeRequest
+	2,  // 21: sturdr.v1.SturdrService.Ingest:input_type -> sturdr.v1.Telemetry
+	13, // 22: sturdr.v1.SturdrService.CreateNavigation:output_type -> google.protobuf.Empty
+	4,  // 23: sturdr.v1.SturdrService.ReadNavigation:output_type -> sturdr.v1.NavigationList
+	13, // 24: sturdr.v1.SturdrService.UpdateNavigation:output_type -> google.protobuf.Empty
+	13, // 25: sturdr.v1.SturdrService.DeleteNavigation:output_type -> google.protobuf.Empty
+	13, // 26: sturdr.v1.SturdrService.CreateSatellite:output_type -> google.protobuf.Empty
+	5,  // 27: sturdr.v1.SturdrService.ReadSatellite:output_type -> sturdr.v1.SatelliteList
+	13, // 28: sturdr.v1.SturdrService.UpdateSatellite:output_type -> google.protobuf.Empty
+	13, // 29: sturdr.v1.SturdrService.DeleteSatellite:output_type -> google.protobuf.Empty
+	13, // 30: sturdr.v1.SturdrService.CreateTelemetry:output_type -> google.protobuf.Empty
+	6,  // 31: sturdr.v1.SturdrService.ReadTelemetry:output_type -> sturdr.v1.TelemetryList
+	13, // 32: sturdr.v1.SturdrService.UpdateTelemetry:output_type -> google.protobuf.Empty
+	13, // 33: sturdr.v1.SturdrService.DeleteTelemetry:output_type -> google.protobuf.Empty
+	2,  // 34: sturdr.v1.SturdrService.Subscribe:output_type -> sturdr.v1.Telemetry
+	12, // 35: sturdr.v1.SturdrService.Ingest:output_type -> sturdr.v1.IngestSummary
+	22, // [22:36] is the sub-list for method output_type
+	8,  // [8:22] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_sturdr_v1_sturdr_proto_init() }
+func file_sturdr_v1_sturdr_proto_init() {
+	if File_sturdr_v1_sturdr_proto != nil {
+		return
+	}
+	file_sturdr_v1_sturdr_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sturdr_v1_sturdr_proto_rawDesc), len(file_sturdr_v1_sturdr_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sturdr_v1_sturdr_proto_goTypes,
+		DependencyIndexes: file_sturdr_v1_sturdr_proto_depIdxs,
+		MessageInfos:      file_sturdr_v1_sturdr_proto_msgTypes,
+	}.Build()
+	File_sturdr_v1_sturdr_proto = out.File
+	file_sturdr_v1_sturdr_proto_goTypes = nil
+	file_sturdr_v1_sturdr_proto_depIdxs = nil
+}
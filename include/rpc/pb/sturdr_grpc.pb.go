@@ -0,0 +1,619 @@
+// Sturdr receiver telemetry (field names match the json keys of the REST-API)
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: sturdr/v1/sturdr.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SturdrService_CreateNavigation_FullMethodName = "/sturdr.v1.SturdrService/CreateNavigation"
+	SturdrService_ReadNavigation_FullMethodName   = "/sturdr.v1.SturdrService/ReadNavigation"
+	SturdrService_UpdateNavigation_FullMethodName = "/sturdr.v1.SturdrService/UpdateNavigation"
+	SturdrService_DeleteNavigation_FullMethodName = "/sturdr.v1.SturdrService/DeleteNavigation"
+	SturdrService_CreateSatellite_FullMethodName  = "/sturdr.v1.SturdrService/CreateSatellite"
+	SturdrService_ReadSatellite_FullMethodName    = "/sturdr.v1.SturdrService/ReadSatellite"
+	SturdrService_UpdateSatellite_FullMethodName  = "/sturdr.v1.SturdrService/UpdateSatellite"
+	SturdrService_DeleteSatellite_FullMethodName  = "/sturdr.v1.SturdrService/DeleteSatellite"
+	SturdrService_CreateTelemetry_FullMethodName  = "/sturdr.v1.SturdrService/CreateTelemetry"
+	SturdrService_ReadTelemetry_FullMethodName    = "/sturdr.v1.SturdrService/ReadTelemetry"
+	SturdrService_UpdateTelemetry_FullMethodName  = "/sturdr.v1.SturdrService/UpdateTelemetry"
+	SturdrService_DeleteTelemetry_FullMethodName  = "/sturdr.v1.SturdrService/DeleteTelemetry"
+	SturdrService_Subscribe_FullMethodName        = "/sturdr.v1.SturdrService/Subscribe"
+	SturdrService_Ingest_FullMethodName           = "/sturdr.v1.SturdrService/Ingest"
+)
+
+// SturdrServiceClient is the client API for SturdrService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SturdrServiceClient interface {
+	CreateNavigation(ctx context.Context, in *Navigation, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReadNavigation(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*NavigationList, error)
+	UpdateNavigation(ctx context.Context, in *UpdateNavigationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteNavigation(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateSatellite(ctx context.Context, in *Satellite, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReadSatellite(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*SatelliteList, error)
+	UpdateSatellite(ctx context.Context, in *UpdateSatelliteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteSatellite(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateTelemetry(ctx context.Context, in *Telemetry, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReadTelemetry(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*TelemetryList, error)
+	UpdateTelemetry(ctx context.Context, in *UpdateTelemetryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteTelemetry(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// every newly created epoch, until the client cancels or the server shuts down
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Telemetry], error)
+	// store a stream of epochs as they arrive (each epoch is its own transaction)
+	Ingest(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Telemetry, IngestSummary], error)
+}
+
+type sturdrServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSturdrServiceClient(cc grpc.ClientConnInterface) SturdrServiceClient {
+	return &sturdrServiceClient{cc}
+}
+
+func (c *sturdrServiceClient) CreateNavigation(ctx context.Context, in *Navigation, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SturdrService_CreateNavigation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) ReadNavigation(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*NavigationList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NavigationList)
+	err := c.cc.Invoke(ctx, SturdrService_ReadNavigation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) UpdateNavigation(ctx context.Context, in *UpdateNavigationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SturdrService_UpdateNavigation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) DeleteNavigation(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SturdrService_DeleteNavigation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) CreateSatellite(ctx context.Context, in *Satellite, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SturdrService_CreateSatellite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) ReadSatellite(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*SatelliteList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SatelliteList)
+	err := c.cc.Invoke(ctx, SturdrService_ReadSatellite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) UpdateSatellite(ctx context.Context, in *UpdateSatelliteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SturdrService_UpdateSatellite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) DeleteSatellite(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SturdrService_DeleteSatellite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) CreateTelemetry(ctx context.Context, in *Telemetry, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SturdrService_CreateTelemetry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) ReadTelemetry(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*TelemetryList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TelemetryList)
+	err := c.cc.Invoke(ctx, SturdrService_ReadTelemetry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) UpdateTelemetry(ctx context.Context, in *UpdateTelemetryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SturdrService_UpdateTelemetry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) DeleteTelemetry(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SturdrService_DeleteTelemetry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sturdrServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Telemetry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SturdrService_ServiceDesc.Streams[0], SturdrService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Telemetry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SturdrService_SubscribeClient = grpc.ServerStreamingClient[Telemetry]
+
+func (c *sturdrServiceClient) Ingest(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Telemetry, IngestSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SturdrService_ServiceDesc.Streams[1], SturdrService_Ingest_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Telemetry, IngestSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SturdrService_IngestClient = grpc.ClientStreamingClient[Telemetry, IngestSummary]
+
+// SturdrServiceServer is the server API for SturdrService service.
+// All implementations must embed UnimplementedSturdrServiceServer
+// for forward compatibility.
+type SturdrServiceServer interface {
+	CreateNavigation(context.Context, *Navigation) (*emptypb.Empty, error)
+	ReadNavigation(context.Context, *ReadRequest) (*NavigationList, error)
+	UpdateNavigation(context.Context, *UpdateNavigationRequest) (*emptypb.Empty, error)
+	DeleteNavigation(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	CreateSatellite(context.Context, *Satellite) (*emptypb.Empty, error)
+	ReadSatellite(context.Context, *ReadRequest) (*SatelliteList, error)
+	UpdateSatellite(context.Context, *UpdateSatelliteRequest) (*emptypb.Empty, error)
+	DeleteSatellite(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	CreateTelemetry(context.Context, *Telemetry) (*emptypb.Empty, error)
+	ReadTelemetry(context.Context, *ReadRequest) (*TelemetryList, error)
+	UpdateTelemetry(context.Context, *UpdateTelemetryRequest) (*emptypb.Empty, error)
+	DeleteTelemetry(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	// every newly created epoch, until the client cancels or the server shuts down
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Telemetry]) error
+	// store a stream of epochs as they arrive (each epoch is its own transaction)
+	Ingest(grpc.ClientStreamingServer[Telemetry, IngestSummary]) error
+	mustEmbedUnimplementedSturdrServiceServer()
+}
+
+// UnimplementedSturdrServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSturdrServiceServer struct{}
+
+func (UnimplementedSturdrServiceServer) CreateNavigation(context.Context, *Navigation) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateNavigation not implemented")
+}
+func (UnimplementedSturdrServiceServer) ReadNavigation(context.Context, *ReadRequest) (*NavigationList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadNavigation not implemented")
+}
+func (UnimplementedSturdrServiceServer) UpdateNavigation(context.Context, *UpdateNavigationRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateNavigation not implemented")
+}
+func (UnimplementedSturdrServiceServer) DeleteNavigation(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteNavigation not implemented")
+}
+func (UnimplementedSturdrServiceServer) CreateSatellite(context.Context, *Satellite) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSatellite not implemented")
+}
+func (UnimplementedSturdrServiceServer) ReadSatellite(context.Context, *ReadRequest) (*SatelliteList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadSatellite not implemented")
+}
+func (UnimplementedSturdrServiceServer) UpdateSatellite(context.Context, *UpdateSatelliteRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSatellite not implemented")
+}
+func (UnimplementedSturdrServiceServer) DeleteSatellite(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSatellite not implemented")
+}
+func (UnimplementedSturdrServiceServer) CreateTelemetry(context.Context, *Telemetry) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTelemetry not implemented")
+}
+func (UnimplementedSturdrServiceServer) ReadTelemetry(context.Context, *ReadRequest) (*TelemetryList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadTelemetry not implemented")
+}
+func (UnimplementedSturdrServiceServer) UpdateTelemetry(context.Context, *UpdateTelemetryRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateTelemetry not implemented")
+}
+func (UnimplementedSturdrServiceServer) DeleteTelemetry(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTelemetry not implemented")
+}
+func (UnimplementedSturdrServiceServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Telemetry]) error {
+	return status.Error(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedSturdrServiceServer) Ingest(grpc.ClientStreamingServer[Telemetry, IngestSummary]) error {
+	return status.Error(codes.Unimplemented, "method Ingest not implemented")
+}
+func (UnimplementedSturdrServiceServer) mustEmbedUnimplementedSturdrServiceServer() {}
+func (UnimplementedSturdrServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSturdrServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SturdrServiceServer will
+// result in compilation errors.
+type UnsafeSturdrServiceServer interface {
+	mustEmbedUnimplementedSturdrServiceServer()
+}
+
+func RegisterSturdrServiceServer(s grpc.ServiceRegistrar, srv SturdrServiceServer) {
+	// If the following call panics, it indicates UnimplementedSturdrServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SturdrService_ServiceDesc, srv)
+}
+
+func _SturdrService_CreateNavigation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Navigation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).CreateNavigation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_CreateNavigation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).CreateNavigation(ctx, req.(*Navigation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_ReadNavigation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).ReadNavigation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_ReadNavigation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).ReadNavigation(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_UpdateNavigation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNavigationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).UpdateNavigation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_UpdateNavigation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).UpdateNavigation(ctx, req.(*UpdateNavigationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_DeleteNavigation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).DeleteNavigation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_DeleteNavigation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).DeleteNavigation(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_CreateSatellite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Satellite)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).CreateSatellite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_CreateSatellite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).CreateSatellite(ctx, req.(*Satellite))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_ReadSatellite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).ReadSatellite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_ReadSatellite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).ReadSatellite(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_UpdateSatellite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSatelliteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).UpdateSatellite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_UpdateSatellite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).UpdateSatellite(ctx, req.(*UpdateSatelliteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_DeleteSatellite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).DeleteSatellite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_DeleteSatellite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).DeleteSatellite(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_CreateTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Telemetry)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).CreateTelemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_CreateTelemetry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).CreateTelemetry(ctx, req.(*Telemetry))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_ReadTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).ReadTelemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_ReadTelemetry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).ReadTelemetry(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_UpdateTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTelemetryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).UpdateTelemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_UpdateTelemetry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).UpdateTelemetry(ctx, req.(*UpdateTelemetryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_DeleteTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SturdrServiceServer).DeleteTelemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SturdrService_DeleteTelemetry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SturdrServiceServer).DeleteTelemetry(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SturdrService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SturdrServiceServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, Telemetry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SturdrService_SubscribeServer = grpc.ServerStreamingServer[Telemetry]
+
+func _SturdrService_Ingest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SturdrServiceServer).Ingest(&grpc.GenericServerStream[Telemetry, IngestSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SturdrService_IngestServer = grpc.ClientStreamingServer[Telemetry, IngestSummary]
+
+// SturdrService_ServiceDesc is the grpc.ServiceDesc for SturdrService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SturdrService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sturdr.v1.SturdrService",
+	HandlerType: (*SturdrServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateNavigation",
+			Handler:    _SturdrService_CreateNavigation_Handler,
+		},
+		{
+			MethodName: "ReadNavigation",
+			Handler:    _SturdrService_ReadNavigation_Handler,
+		},
+		{
+			MethodName: "UpdateNavigation",
+			Handler:    _SturdrService_UpdateNavigation_Handler,
+		},
+		{
+			MethodName: "DeleteNavigation",
+			Handler:    _SturdrService_DeleteNavigation_Handler,
+		},
+		{
+			MethodName: "CreateSatellite",
+			Handler:    _SturdrService_CreateSatellite_Handler,
+		},
+		{
+			MethodName: "ReadSatellite",
+			Handler:    _SturdrService_ReadSatellite_Handler,
+		},
+		{
+			MethodName: "UpdateSatellite",
+			Handler:    _SturdrService_UpdateSatellite_Handler,
+		},
+		{
+			MethodName: "DeleteSatellite",
+			Handler:    _SturdrService_DeleteSatellite_Handler,
+		},
+		{
+			MethodName: "CreateTelemetry",
+			Handler:    _SturdrService_CreateTelemetry_Handler,
+		},
+		{
+			MethodName: "ReadTelemetry",
+			Handler:    _SturdrService_ReadTelemetry_Handler,
+		},
+		{
+			MethodName: "UpdateTelemetry",
+			Handler:    _SturdrService_UpdateTelemetry_Handler,
+		},
+		{
+			MethodName: "DeleteTelemetry",
+			Handler:    _SturdrService_DeleteTelemetry_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _SturdrService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Ingest",
+			Handler:       _SturdrService_Ingest_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "sturdr/v1/sturdr.proto",
+}
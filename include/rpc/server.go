@@ -0,0 +1,215 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/rpc/pb"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// gRPC service backed by the same services as the http handlers
+type Server struct {
+	pb.UnimplementedSturdrServiceServer
+	navigation navigation.Service
+	satellite  satellite.Service
+	telemetry  telemetry.Service
+	broker     *telemetry.Broker
+}
+
+func NewServer(n navigation.Service, sv satellite.Service, t telemetry.Service, b *telemetry.Broker) *Server {
+	return &Server{navigation: n, satellite: sv, telemetry: t, broker: b}
+}
+
+// --- navigation ---
+
+func (s *Server) CreateNavigation(ctx context.Context, m *pb.Navigation) (*emptypb.Empty, error) {
+	n, err := toNavigation(m)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.navigation.CreateNavigation(ctx, n); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ReadNavigation(ctx context.Context, req *pb.ReadRequest) (*pb.NavigationList, error) {
+	week, tow, _, since, err := readRange(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	items, err := s.navigation.ReadNavigation(ctx, week, tow, since, nil)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	res := &pb.NavigationList{Items: make([]*pb.Navigation, len(items))}
+	for i := range items {
+		res.Items[i] = fromNavigation(&items[i])
+	}
+	return res, nil
+}
+
+func (s *Server) UpdateNavigation(ctx context.Context, req *pb.UpdateNavigationRequest) (*emptypb.Empty, error) {
+	n, err := toNavigation(req.Navigation)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.navigation.UpdateNavigation(ctx, n, req.Id); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteNavigation(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := s.navigation.DeleteNavigation(ctx, req.Id); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// --- satellite ---
+
+func (s *Server) CreateSatellite(ctx context.Context, m *pb.Satellite) (*emptypb.Empty, error) {
+	sv, err := toSatellite(m)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.satellite.CreateSatellite(ctx, sv); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ReadSatellite(ctx context.Context, req *pb.ReadRequest) (*pb.SatelliteList, error) {
+	week, tow, prn, since, err := readRange(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	items, err := s.satellite.ReadSatellite(ctx, week, tow, prn, since, nil)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	res := &pb.SatelliteList{Items: make([]*pb.Satellite, len(items))}
+	for i := range items {
+		res.Items[i] = fromSatellite(&items[i])
+	}
+	return res, nil
+}
+
+func (s *Server) UpdateSatellite(ctx context.Context, req *pb.UpdateSatelliteRequest) (*emptypb.Empty, error) {
+	sv, err := toSatellite(req.Satellite)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.satellite.UpdateSatellite(ctx, sv, req.Id); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteSatellite(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := s.satellite.DeleteSatellite(ctx, req.Id); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// --- telemetry ---
+
+func (s *Server) CreateTelemetry(ctx context.Context, m *pb.Telemetry) (*emptypb.Empty, error) {
+	data, err := toTelemetry(m)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.telemetry.CreateTelemetry(ctx, data); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ReadTelemetry(ctx context.Context, req *pb.ReadRequest) (*pb.TelemetryList, error) {
+	week, tow, _, since, err := readRange(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	items, err := s.telemetry.ReadTelemetry(ctx, week, tow, since, nil)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	res := &pb.TelemetryList{Items: make([]*pb.Telemetry, len(items))}
+	for i := range items {
+		res.Items[i] = fromTelemetry(&items[i])
+	}
+	return res, nil
+}
+
+func (s *Server) UpdateTelemetry(ctx context.Context, req *pb.UpdateTelemetryRequest) (*emptypb.Empty, error) {
+	data, err := toTelemetry(req.Telemetry)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.telemetry.UpdateTelemetry(ctx, data, req.Sequence); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteTelemetry(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := s.telemetry.DeleteTelemetry(ctx, req.Id); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// --- streams ---
+
+// Send every newly created epoch until the client cancels or the broker closes (server shutdown)
+func (s *Server) Subscribe(req *pb.SubscribeRequest, stream grpc.ServerStreamingServer[pb.Telemetry]) error {
+	ch, cancel := s.broker.Subscribe()
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case data, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(fromTelemetry(&data)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Store epochs as they arrive, the summary is sent when the client closes its side
+func (s *Server) Ingest(stream grpc.ClientStreamingServer[pb.Telemetry, pb.IngestSummary]) error {
+	var count uint64
+	for {
+		m, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&pb.IngestSummary{Epochs: count})
+		} else if err != nil {
+			return err
+		}
+
+		data, err := toTelemetry(m)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "epoch %d: %s", count, err.Error())
+		}
+		if err := s.telemetry.CreateTelemetry(stream.Context(), data); err != nil {
+			return status.Errorf(codes.InvalidArgument, "epoch %d (sequence %d): %s", count, data.Navigation.Sequence, err.Error())
+		}
+		count++
+	}
+}
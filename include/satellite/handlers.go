@@ -1,32 +1,58 @@
 package satellite
 
 import (
-	"log"
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/sturdivant20/sturdr-api/include/decimate"
 	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/logging"
 )
 
+var logger = logging.For("satellite")
+
 type Handler struct {
 	service Service
+	replay  Service // nil without a replay controller
 }
 
 func NewHttpHandler(s Service) *Handler {
 	return &Handler{service: s}
 }
 
+// Attach the read-only replay view that serves reads with "source=replay"
+func (h *Handler) WithReplay(s Service) *Handler {
+	h.replay = s
+	return h
+}
+
+// Service of a read, the stored data or the replayed epochs played so far ("source=database" or "source=replay")
+func (h *Handler) reader(r *http.Request) (Service, error) {
+	switch source := r.URL.Query().Get("source"); source {
+	case "", "database":
+		return h.service, nil
+	case "replay":
+		if h.replay != nil {
+			return h.replay, nil
+		}
+		fallthrough
+	default:
+		return nil, fmt.Errorf("source must be 'database' or 'replay', got '%s'", source)
+	}
+}
+
 // Handle http create satellite json request
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	var sv Satellite
-	if err := readRequest(r, &sv); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := encoder.Read(r, &sv); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
 		return
 	}
 
 	// create satellite using service
-	if err := h.service.createSatellite(r.Context(), sv); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := h.service.CreateSatellite(r.Context(), sv); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
@@ -39,15 +65,122 @@ func (h *Handler) Read(w http.ResponseWriter, r *http.Request) {
 	// request navigation by gps week, tow, and prn
 	week, tow, prn, do_query := parseQuery(r)
 
+	// tracking loop diagnostics of the correlators with "derived=true" (EX: "&derived=true&fields=tow,prn,dll,pll")
+	derived, _ := strconv.ParseBool(r.URL.Query().Get("derived"))
+	var record any = Satellite{}
+	var tracking TrackingOptions
+	if derived {
+		record = Derived{}
+		var err error
+		if tracking, err = ParseTrackingOptions(r.URL.Query()); err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	// limit the columns to "fields=" (EX: "tow,doppler,cno")
+	fields, err := encoder.ParseFields(r.URL.Query().Get("fields"), record)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// downsample long histories ("every=", "bucket=" or "points=")
+	opts, err := decimate.ParseOptions(r.URL.Query(), "cno")
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	read_fields := fields
+	if derived {
+		read_fields = nil // the diagnostics need the correlator columns
+	} else if fields != nil && opts.Enabled() {
+		// the times (and the preserved field) are read even when they are not sent
+		spec := r.URL.Query().Get("fields") + ",week,tow,prn," + opts.By
+		if read_fields, err = encoder.ParseFields(spec, Satellite{}); err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	}
+
 	// read specific satellite from table
-	sv, err := h.service.readSatellite(r.Context(), week, tow, prn, do_query)
+	service, err := h.reader(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	sv, err := service.ReadSatellite(r.Context(), week, tow, prn, do_query, read_fields)
+	if err != nil {
+		handleError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	// every prn is its own series (the diagnostics are derived before downsampling)
+	var out any
+	if derived {
+		prn_of := func(d *Derived) uint8 { return d.PRN }
+		out, err = decimate.Grouped(Derive(sv, tracking), prn_of, (*Derived).GpsTime, opts)
+	} else {
+		prn_of := func(sv *Satellite) uint8 { return sv.PRN }
+		out, err = decimate.Grouped(sv, prn_of, (*Satellite).GpsTime, opts)
+	}
 	if err != nil {
-		handleError(w, err, http.StatusNotFound)
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
-	if err := writeResponse(w, r, sv); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := encoder.Write(w, r, http.StatusOK, fields.Project(out)); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+}
+
+// Handle http multipath request, the code-minus-carrier of every continuous carrier arc of the read with its
+// detrended multipath indicator, rms and ionosphere divergence (EX: "/satellite/multipath?week=2300&tow=0&prn=5")
+func (h *Handler) Multipath(w http.ResponseWriter, r *http.Request) {
+	week, tow, prn, do_query := parseQuery(r)
+	tracking, err := ParseTrackingOptions(r.URL.Query())
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// downsample every arc series ("every=", "bucket=" or "points="), the statistics use every epoch
+	opts, err := decimate.ParseOptions(r.URL.Query(), "multipath")
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	service, err := h.reader(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	sv, err := service.ReadSatellite(r.Context(), week, tow, prn, do_query, nil)
+	if err != nil {
+		handleError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	// 1. arcs of every prn
+	arcs := MultipathArcs(sv, tracking)
+	if len(arcs) == 0 {
+		handleError(w, r, fmt.Errorf("no satellite measurements with code and carrier in the requested epochs"),
+			http.StatusNotFound)
+		return
+	}
+
+	// 2. downsample the series
+	for i := range arcs {
+		if arcs[i].Series, err = decimate.Series(arcs[i].Series, (*MultipathPoint).GpsTime, opts); err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := encoder.Write(w, r, http.StatusOK, arcs); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
 		return
 	}
 }
@@ -57,20 +190,20 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	// request satellite by id
 	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
 	if err != nil {
-		handleError(w, err, http.StatusBadRequest)
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	// decode incoming json data
 	var sv Satellite
-	if err := readRequest(r, &sv); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := encoder.Read(r, &sv); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
 		return
 	}
 
 	// update held satellite
-	if err := h.service.updateSatellite(r.Context(), sv, id); err != nil {
-		handleError(w, err, http.StatusExpectationFailed)
+	if err := h.service.UpdateSatellite(r.Context(), sv, id); err != nil {
+		handleError(w, r, err, http.StatusExpectationFailed)
 		return
 	}
 
@@ -83,13 +216,13 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	// request satellite by id
 	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
 	if err != nil {
-		handleError(w, err, http.StatusBadRequest)
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	// delete held satellite
-	if err := h.service.deleteSatellite(r.Context(), id); err != nil {
-		handleError(w, err, http.StatusExpectationFailed)
+	if err := h.service.DeleteSatellite(r.Context(), id); err != nil {
+		handleError(w, r, err, http.StatusExpectationFailed)
 		return
 	}
 
@@ -98,47 +231,11 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 }
 
 // Reusable error handler
-func handleError(w http.ResponseWriter, e error, c int) {
-	log.Printf("Satellite error %d! %s", c, e.Error())
+func handleError(w http.ResponseWriter, r *http.Request, e error, c int) {
+	logger.WarnContext(r.Context(), "Satellite error!", "status", c, "error", e)
 	http.Error(w, e.Error(), c)
 }
 
-// Reusable read function
-func readRequest(r *http.Request, sv *Satellite) error {
-	switch r.URL.Query().Get("format") {
-	case "binary":
-		if err := encoder.ReadBinary(r, sv); err != nil {
-			return err
-		}
-	case "json":
-		fallthrough
-	default:
-		if err := encoder.ReadJson(r, sv); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// Reusable write function
-func writeResponse(w http.ResponseWriter, r *http.Request, sv []Satellite) error {
-	switch r.URL.Query().Get("format") {
-	case "binary":
-		if err := encoder.WriteBinary(w, http.StatusOK, sv); err != nil {
-			return err
-		}
-	case "json":
-		fallthrough
-	default:
-		if err := encoder.WriteJson(w, http.StatusOK, sv); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func parseQuery(r *http.Request) (uint16, float32, uint8, bool) {
 	query := r.URL.Query()
 	s_week := query.Get("week")
@@ -0,0 +1,194 @@
+package satellite
+
+import (
+	"math"
+
+	"github.com/sturdivant20/sturdr-api/include/analysis"
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+)
+
+const l1Lambda = geodesy.C / 1575.42e6 // gps L1 / galileo E1 carrier wavelength [m]
+
+// Code-minus-carrier of one epoch of an arc
+type MultipathPoint struct {
+	Week      uint16   `json:"week"`
+	ToW       float32  `json:"tow"`
+	Elevation float32  `json:"elevation"` // [deg]
+	CNo       float32  `json:"cno"`       // [dB-Hz]
+	CMC       float64  `json:"cmc"`       // pseudorange minus carrier range, less the arc mean [m]
+	Multipath *float64 `json:"multipath"` // cmc less its trend [m] (null for arcs below min_arc epochs)
+	IonoRate  *float64 `json:"iono_rate"` // ionosphere divergence, half the slope of the trend [m/s] (null as multipath)
+}
+
+func (p *MultipathPoint) GpsTime() float64 {
+	return float64(p.Week)*604800 + float64(p.ToW)
+}
+
+// Continuous carrier tracking arc of one satellite
+type Arc struct {
+	PRN            uint8            `json:"prn"`
+	Arc            int              `json:"arc"` // arc of the prn in the span (from 1)
+	StartWeek      uint16           `json:"start_week"`
+	StartToW       float32          `json:"start_tow"`
+	EndWeek        uint16           `json:"end_week"`
+	EndToW         float32          `json:"end_tow"`
+	Epochs         int              `json:"epochs"`
+	Elevation      float64          `json:"elevation"`       // mean [deg]
+	Multipath      analysis.Summary `json:"multipath"`       // of the detrended cmc [m] (empty below min_arc epochs)
+	IonoDivergence *float64         `json:"iono_divergence"` // mean ionosphere rate over the arc [m/s] (null as multipath)
+	Series         []MultipathPoint `json:"series"`
+}
+
+// Split time ordered records into the continuous carrier arcs of every satellite (indices of the records, ordered
+// by prn and time), an arc ends at a gap, a record without carrier or code, time going back or a cmc jump
+func splitArcs(items []Satellite, opts TrackingOptions) [][]int {
+	var arcs [][]int
+	open := make(map[uint8]int) // prn -> index of its open arc
+	for i := range items {
+		sv := &items[i]
+		k, ok := open[sv.PRN]
+		if sv.ADR == 0 || sv.PSR == 0 {
+			delete(open, sv.PRN)
+			continue
+		}
+		if ok {
+			last := &items[arcs[k][len(arcs[k])-1]]
+			dt := sv.GpsTime() - last.GpsTime()
+			jump := math.Abs(cmc(sv, opts.AdrSign) - cmc(last, opts.AdrSign))
+			ok = dt > 0 && dt <= opts.MaxGap && jump <= opts.CmcJump
+		}
+		if !ok {
+			arcs = append(arcs, nil)
+			k = len(arcs) - 1
+			open[sv.PRN] = k
+		}
+		arcs[k] = append(arcs[k], i)
+	}
+
+	// order by prn, arcs of a prn were opened in time order
+	by_prn := make(map[uint8][][]int)
+	for _, a := range arcs {
+		prn := items[a[0]].PRN
+		by_prn[prn] = append(by_prn[prn], a)
+	}
+	out := make([][]int, 0, len(arcs))
+	for prn := range 256 {
+		out = append(out, by_prn[uint8(prn)]...)
+	}
+	return out
+}
+
+// Code-minus-carrier arcs of time ordered records, with the multipath of arcs of at least opts.MinArc epochs
+func MultipathArcs(items []Satellite, opts TrackingOptions) []Arc {
+	out := []Arc{}
+	n := make(map[uint8]int)
+	for _, idx := range splitArcs(items, opts) {
+		first, last := &items[idx[0]], &items[idx[len(idx)-1]]
+		n[first.PRN]++
+		arc := Arc{
+			PRN: first.PRN, Arc: n[first.PRN], Epochs: len(idx),
+			StartWeek: first.Week, StartToW: first.ToW, EndWeek: last.Week, EndToW: last.ToW,
+			Series: fitArc(items, idx, opts),
+		}
+		mp := make([]float64, 0, len(idx))
+		iono := 0.0
+		for _, p := range arc.Series {
+			arc.Elevation += float64(p.Elevation) / float64(len(idx))
+			if p.Multipath != nil {
+				mp = append(mp, *p.Multipath)
+				iono += *p.IonoRate / float64(len(idx))
+			}
+		}
+		arc.Multipath = analysis.Summarize(mp)
+		if len(mp) > 0 {
+			arc.IonoDivergence = &iono
+		}
+		out = append(out, arc)
+	}
+	return out
+}
+
+// Code-minus-carrier of an arc less its mean, detrended by a quadratic least-squares fit in time (the trend is the
+// ionosphere, whose code delay and carrier advance diverge by twice its delay), arcs shorter than opts.MinArc have
+// no multipath and rate
+func fitArc(items []Satellite, idx []int, opts TrackingOptions) []MultipathPoint {
+	pts := make([]MultipathPoint, len(idx))
+	t0 := items[idx[0]].GpsTime()
+	span := max(items[idx[len(idx)-1]].GpsTime()-t0, 1)
+	u := make([]float64, len(idx)) // time scaled to [0, 1]
+	y := make([]float64, len(idx))
+	mean := 0.0
+	for k, i := range idx {
+		sv := &items[i]
+		u[k] = (sv.GpsTime() - t0) / span
+		y[k] = cmc(sv, opts.AdrSign)
+		mean += y[k] / float64(len(idx))
+		pts[k] = MultipathPoint{Week: sv.Week, ToW: sv.ToW, Elevation: sv.Elevation, CNo: sv.CNo}
+	}
+	for k := range y {
+		y[k] -= mean
+		pts[k].CMC = y[k]
+	}
+	if len(idx) < max(opts.MinArc, 3) {
+		return pts
+	}
+
+	c, ok := polyfit2(u, y)
+	if !ok {
+		return pts
+	}
+	for k := range pts {
+		mp := y[k] - (c[0] + c[1]*u[k] + c[2]*u[k]*u[k])
+		rate := 0.5 * (c[1] + 2*c[2]*u[k]) / span
+		pts[k].Multipath, pts[k].IonoRate = &mp, &rate
+	}
+	return pts
+}
+
+// Pseudorange minus the carrier range [m]
+func cmc(sv *Satellite, adr_sign float64) float64 {
+	return float64(sv.PSR) - adr_sign*l1Lambda*float64(sv.ADR)
+}
+
+// Least-squares coefficients of y = c0 + c1 u + c2 u^2 (false for fewer than 3 distinct times)
+func polyfit2(u []float64, y []float64) ([3]float64, bool) {
+	var m [3][4]float64 // normal equations augmented with the right hand side
+	for k := range u {
+		p := [3]float64{1, u[k], u[k] * u[k]}
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				m[i][j] += p[i] * p[j]
+			}
+			m[i][3] += p[i] * y[k]
+		}
+	}
+
+	// gaussian elimination with partial pivoting
+	for c := 0; c < 3; c++ {
+		p := c
+		for r := c + 1; r < 3; r++ {
+			if math.Abs(m[r][c]) > math.Abs(m[p][c]) {
+				p = r
+			}
+		}
+		if math.Abs(m[p][c]) < 1e-12 {
+			return [3]float64{}, false
+		}
+		m[c], m[p] = m[p], m[c]
+		for r := c + 1; r < 3; r++ {
+			f := m[r][c] / m[c][c]
+			for j := c; j < 4; j++ {
+				m[r][j] -= f * m[c][j]
+			}
+		}
+	}
+	var x [3]float64
+	for i := 2; i >= 0; i-- {
+		x[i] = m[i][3]
+		for j := i + 1; j < 3; j++ {
+			x[i] -= m[i][j] * x[j]
+		}
+		x[i] /= m[i][i]
+	}
+	return x, true
+}
@@ -0,0 +1,159 @@
+package satellite
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// Record of a prn at a time [s] whose code-minus-carrier is cmc [m] (a constant carrier, so the code carries it)
+func testCarrier(prn uint8, t float64, cmc float64) Satellite {
+	return Satellite{Week: 2300, ToW: float32(1000 + t), PRN: prn, ADR: 1000, PSR: float32(1000*l1Lambda + cmc),
+		Elevation: 30, CNo: 40}
+}
+
+func TestPolyfit2(t *testing.T) {
+	u := []float64{0, 0.1, 0.25, 0.5, 0.6, 0.9, 1}
+	y := make([]float64, len(u))
+	for k := range u {
+		y[k] = 1.5 - 2*u[k] + 0.75*u[k]*u[k]
+	}
+	c, ok := polyfit2(u, y)
+	if !ok || math.Abs(c[0]-1.5) > 1e-9 || math.Abs(c[1]+2) > 1e-9 || math.Abs(c[2]-0.75) > 1e-9 {
+		t.Errorf("coefficients %v (%v), want [1.5 -2 0.75]", c, ok)
+	}
+
+	// a line through 3 distinct times has no curvature, 2 distinct times have no fit
+	if c, ok := polyfit2([]float64{0, 0.5, 1}, []float64{1, 2, 3}); !ok || math.Abs(c[2]) > 1e-9 {
+		t.Errorf("line coefficients %v (%v)", c, ok)
+	}
+	if _, ok := polyfit2([]float64{0, 0, 1, 1}, []float64{1, 2, 3, 4}); ok {
+		t.Error("fit of 2 distinct times")
+	}
+}
+
+func TestSplitArcs(t *testing.T) {
+	opts := DefaultTrackingOptions // 5 s gap, 10 m jump
+
+	// prn 4 every second from 0 to 24 s: an 8 s gap after 4 s, no carrier at 9 s, a 15 m jump at 14 s and time going
+	// back at 17 s; prn 2 runs through it
+	var items []Satellite
+	for k := 0; k < 20; k++ {
+		items = append(items, testCarrier(2, float64(k), 0))
+		tt, v := float64(k), 0.0
+		switch {
+		case k > 4 && k < 7:
+			continue
+		case k >= 7:
+			tt += 5
+		}
+		if k >= 14 {
+			v = 15
+		}
+		sv := testCarrier(4, tt, v)
+		switch k {
+		case 9:
+			sv.ADR = 0
+		case 17:
+			sv.ToW -= 3
+		}
+		items = append(items, sv)
+	}
+
+	arcs := splitArcs(items, opts)
+	want := [][]float64{
+		{1000, 1001, 1002, 1003, 1004, 1005, 1006, 1007, 1008, 1009, 1010, 1011, 1012, 1013, 1014, 1015, 1016, 1017,
+			1018, 1019},
+		{1000, 1001, 1002, 1003, 1004},
+		{1012, 1013},
+		{1015, 1016, 1017, 1018},
+		{1019, 1020, 1021},
+		{1019, 1023, 1024},
+	}
+	if len(arcs) != len(want) {
+		t.Fatalf("%d arcs, want %d", len(arcs), len(want))
+	}
+	for a, idx := range arcs {
+		tows := make([]float64, len(idx))
+		for k, i := range idx {
+			tows[k] = float64(items[i].ToW)
+		}
+		if !slices.Equal(tows, want[a]) {
+			t.Errorf("arc %d at %v, want %v", a, tows, want[a])
+		}
+	}
+}
+
+func TestFitArc(t *testing.T) {
+	// a quadratic (the ionosphere divergence) plus a 0.5 m, 15 s multipath over 300 s
+	trend := func(t float64) float64 { return 3 + 0.02*t - 1e-4*t*t }
+	wave := func(t float64) float64 { return 0.5 * math.Sin(2*math.Pi*t/15) }
+	var items []Satellite
+	idx := make([]int, 301)
+	for k := range idx {
+		items = append(items, testCarrier(1, float64(k), trend(float64(k))+wave(float64(k))))
+		idx[k] = k
+	}
+
+	opts := DefaultTrackingOptions
+	pts := fitArc(items, idx, opts)
+	mean := 0.0
+	for k, p := range pts {
+		tt := float64(k)
+		if p.Multipath == nil || p.IonoRate == nil {
+			t.Fatalf("epoch %d: no multipath on a %d epoch arc", k, len(pts))
+		}
+		if math.Abs(*p.Multipath-wave(tt)) > 0.03 {
+			t.Errorf("epoch %d: multipath %g, want %g", k, *p.Multipath, wave(tt))
+		}
+		if math.Abs(*p.IonoRate-0.5*(0.02-2e-4*tt)) > 1e-3 {
+			t.Errorf("epoch %d: iono rate %g, want %g", k, *p.IonoRate, 0.5*(0.02-2e-4*tt))
+		}
+		mean += p.CMC / float64(len(pts))
+	}
+	if math.Abs(mean) > 1e-6 {
+		t.Errorf("cmc mean %g, want 0", mean)
+	}
+
+	// an arc shorter than min_arc keeps its cmc without multipath
+	for _, p := range fitArc(items, idx[:opts.MinArc-1], opts) {
+		if p.Multipath != nil || p.IonoRate != nil {
+			t.Fatalf("multipath %g on an arc of %d epochs", *p.Multipath, opts.MinArc-1)
+		}
+	}
+}
+
+func TestMultipathArcs(t *testing.T) {
+	// a 30 epoch arc, a slip, then a 5 epoch arc
+	var items []Satellite
+	for k := 0; k < 35; k++ {
+		v := 0.0
+		if k >= 30 {
+			v = 50
+		}
+		items = append(items, testCarrier(6, float64(k), v+0.1*math.Sin(float64(k))))
+	}
+	arcs := MultipathArcs(items, DefaultTrackingOptions)
+	if len(arcs) != 2 {
+		t.Fatalf("%d arcs, want 2", len(arcs))
+	}
+	if a := arcs[0]; a.Arc != 1 || a.Epochs != 30 || a.Multipath.Count != 30 || a.IonoDivergence == nil ||
+		a.EndToW != 1029 || a.Elevation != 30 {
+		t.Errorf("first arc %+v", a)
+	}
+	if a := arcs[1]; a.Arc != 2 || a.Epochs != 5 || a.Multipath.Count != 0 || a.IonoDivergence != nil ||
+		a.StartToW != 1030 {
+		t.Errorf("short arc %+v", a)
+	}
+
+	// the derived records carry the arc of their carrier, and no cmc without one
+	items[10].ADR = 0
+	for i, d := range Derive(items, DefaultTrackingOptions) {
+		switch {
+		case i == 10 && (d.Arc != 0 || d.CMC != nil || d.Multipath != nil):
+			t.Errorf("record without carrier in arc %d", d.Arc)
+		case i < 10 && d.Arc != 1, i > 10 && i < 30 && d.Arc != 2, i >= 30 && (d.Arc != 3 || d.Multipath != nil):
+			t.Errorf("record %d in arc %d (multipath %v)", i, d.Arc, d.Multipath)
+		}
+	}
+}
@@ -3,16 +3,19 @@ package satellite
 import (
 	"context"
 	"database/sql"
-	"log"
 	"os"
 	"strings"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/metrics"
 )
 
 type Service interface {
-	createSatellite(ctx context.Context, sv Satellite) error
-	readSatellite(ctx context.Context, week uint16, tow float32, prn uint8, do_query bool) ([]Satellite, error)
-	updateSatellite(ctx context.Context, sv Satellite, id int64) error
-	deleteSatellite(ctx context.Context, id int64) error
+	CreateSatellite(ctx context.Context, sv Satellite) error
+	ReadSatellite(ctx context.Context, week uint16, tow float32, prn uint8, do_query bool, fields *encoder.Fields) ([]Satellite, error)
+	UpdateSatellite(ctx context.Context, sv Satellite, id int64) error
+	DeleteSatellite(ctx context.Context, id int64) error
 }
 
 type SatelliteService struct {
@@ -24,6 +27,7 @@ type SatelliteService struct {
 	ReadQuerySpecificStmt  *sql.Stmt
 	UpdateStmt             *sql.Stmt
 	DeleteStmt             *sql.Stmt
+	reads                  map[string]string // sql of the read statements (projections select fewer columns)
 }
 
 // Initialize/create local satellite database file
@@ -31,7 +35,8 @@ func NewSatelliteService(db *sql.DB, sql_fname string) Service {
 	// --- read sql commands from file ---
 	data, err := os.ReadFile(sql_fname)
 	if err != nil {
-		log.Fatalf("Error reading '%s': %s", sql_fname, err.Error())
+		logger.Error("Error reading SQL file!", "file", sql_fname, "error", err)
+		os.Exit(1)
 	}
 	cmd := strings.Split(string(data), ";")
 
@@ -40,7 +45,7 @@ func NewSatelliteService(db *sql.DB, sql_fname string) Service {
 	statement.Exec()
 	statement = bindStatement(db, cmd[1], "create_satellite_index")
 	statement.Exec()
-	log.Printf("Created 'satellite' database table ...")
+	logger.Info("Created database table ...", "table", "satellite")
 
 	// --- bind statements ---
 	return &SatelliteService{
@@ -51,62 +56,89 @@ func NewSatelliteService(db *sql.DB, sql_fname string) Service {
 		ReadLatestSpecificStmt: bindStatement(db, strings.TrimSpace(cmd[5]), "read_latest_specific_satellite"),
 		ReadQuerySpecificStmt:  bindStatement(db, strings.TrimSpace(cmd[6]), "read_queried_specific_satellite"),
 		UpdateStmt:             bindStatement(db, strings.TrimSpace(cmd[7]), "update_satellite"),
-		DeleteStmt:             bindStatement(db, strings.TrimSpace(cmd[8]), "delete_satellite")}
+		DeleteStmt:             bindStatement(db, strings.TrimSpace(cmd[8]), "delete_satellite"),
+		reads: map[string]string{
+			"read_latest_satellite":           strings.TrimSpace(cmd[3]),
+			"read_queried_satellite":          strings.TrimSpace(cmd[4]),
+			"read_latest_specific_satellite":  strings.TrimSpace(cmd[5]),
+			"read_queried_specific_satellite": strings.TrimSpace(cmd[6])}}
 }
 
 // Add a Satellite to the table
-func (s *SatelliteService) createSatellite(ctx context.Context, sv Satellite) error {
+func (s *SatelliteService) CreateSatellite(ctx context.Context, sv Satellite) error {
+	defer metrics.ObserveStatement("create_satellite", time.Now())
 	_, err := s.CreateStmt.ExecContext(ctx, sv.Args()...)
 	return err
 }
 
-// Read Satellite from the table
-func (s *SatelliteService) readSatellite(
-	ctx context.Context, week uint16, tow float32, prn uint8, do_query bool) ([]Satellite, error) {
+// Read Satellite from the table (only the selected columns when fields are given)
+func (s *SatelliteService) ReadSatellite(
+	ctx context.Context, week uint16, tow float32, prn uint8, do_query bool, fields *encoder.Fields) ([]Satellite, error) {
 
-	var rows *sql.Rows
-	var err error
+	var stmt *sql.Stmt
+	var name string
+	var args []any
 	if do_query {
 		// query specific time range
 		if prn != 255 {
 			// valid prn
-			rows, err = s.ReadQuerySpecificStmt.QueryContext(ctx, week, tow, prn)
+			stmt, name, args = s.ReadQuerySpecificStmt, "read_queried_specific_satellite", []any{week, tow, prn}
 		} else {
 			// invalid/all prn
-			rows, err = s.ReadQueryStmt.QueryContext(ctx, week, tow)
+			stmt, name, args = s.ReadQueryStmt, "read_queried_satellite", []any{week, tow}
 		}
 	} else {
 		// query latest
 		if prn != 255 {
 			// valid prn
-			rows, err = s.ReadLatestSpecificStmt.QueryContext(ctx, prn)
+			stmt, name, args = s.ReadLatestSpecificStmt, "read_latest_specific_satellite", []any{prn}
 		} else {
 			// invalid prn
-			rows, err = s.ReadLatestStmt.QueryContext(ctx)
+			stmt, name, args = s.ReadLatestStmt, "read_latest_satellite", nil
 		}
 	}
+	defer metrics.ObserveStatement(name, time.Now())
+	var rows *sql.Rows
+	var err error
+	if fields == nil {
+		rows, err = stmt.QueryContext(ctx, args...)
+	} else {
+		var sv Satellite
+		cols, _ := fields.Select(sv.Args())
+		rows, err = s.db.QueryContext(ctx, encoder.SelectColumns(s.reads[name], cols), args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
 	// add satellites to list
 	var items []Satellite
 	for rows.Next() {
 		var sv Satellite
-		if err := rows.Scan(sv.Args()...); err != nil {
+		targets := sv.Args()
+		if fields != nil {
+			_, targets = fields.Select(targets)
+		}
+		if err := rows.Scan(targets...); err != nil {
 			return nil, err
 		}
 		items = append(items, sv)
 	}
 
-	return items, err
+	return items, rows.Err()
 }
 
 // Update a Satellite from the table
-func (s *SatelliteService) updateSatellite(ctx context.Context, sv Satellite, id int64) error {
+func (s *SatelliteService) UpdateSatellite(ctx context.Context, sv Satellite, id int64) error {
+	defer metrics.ObserveStatement("update_satellite", time.Now())
 	_, err := s.UpdateStmt.ExecContext(ctx, append(sv.Args(), id)...)
 	return err
 }
 
 // Delete a Satellite from the table
-func (s *SatelliteService) deleteSatellite(ctx context.Context, id int64) error {
+func (s *SatelliteService) DeleteSatellite(ctx context.Context, id int64) error {
+	defer metrics.ObserveStatement("delete_satellite", time.Now())
 	_, err := s.DeleteStmt.ExecContext(ctx, id)
 	return err
 }
@@ -115,7 +147,8 @@ func (s *SatelliteService) deleteSatellite(ctx context.Context, id int64) error
 func bindStatement(db *sql.DB, cmd string, name string) *sql.Stmt {
 	create_stmt, err := db.Prepare(cmd)
 	if err != nil {
-		log.Fatalf("Error preparing '%s' statement: %s", name, err.Error())
+		logger.Error("Error preparing statement!", "statement", name, "error", err)
+		os.Exit(1)
 	}
 	return create_stmt
 }
@@ -0,0 +1,210 @@
+package satellite
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+)
+
+// Satellite with the tracking loop diagnostics of its correlators ("derived=true")
+type Derived struct {
+	Sequence  uint64   `json:"sequence"`
+	Week      uint16   `json:"week"`
+	ToW       float32  `json:"tow"`
+	PRN       uint8    `json:"prn"`
+	Health    uint8    `json:"health"`
+	X         float32  `json:"x"`
+	Y         float32  `json:"y"`
+	Z         float32  `json:"z"`
+	Vx        float32  `json:"vx"`
+	Vy        float32  `json:"vy"`
+	Vz        float32  `json:"vz"`
+	Doppler   float32  `json:"doppler"`
+	PSR       float32  `json:"psr"`
+	ADR       float32  `json:"adr"`
+	Azimuth   float32  `json:"azimuth"`
+	Elevation float32  `json:"elevation"`
+	CNo       float32  `json:"cno"`
+	IE        float32  `json:"ie"`
+	IP        float32  `json:"ip"`
+	IL        float32  `json:"il"`
+	QE        float32  `json:"qe"`
+	QP        float32  `json:"qp"`
+	QL        float32  `json:"ql"`
+	DLL       float64  `json:"dll"`       // normalized early-minus-late envelope discriminator [chips]
+	PLL       float64  `json:"pll"`       // costas discriminator atan(QP/IP) [rad]
+	PLI       float64  `json:"pli"`       // phase lock indicator (IP^2-QP^2)/(IP^2+QP^2), 1 when locked
+	CNoNWPR   *float64 `json:"cno_nwpr"`  // narrow/wide-band power ratio C/No of the preceding window [dB-Hz] (null until full)
+	Arc       int      `json:"arc"`       // carrier tracking arc of the prn in the read (from 1, 0 without carrier)
+	CMC       *float64 `json:"cmc"`       // code-minus-carrier less its arc mean [m] (null without carrier)
+	Multipath *float64 `json:"multipath"` // detrended code-minus-carrier [m] (null for arcs below min_arc epochs)
+	IonoRate  *float64 `json:"iono_rate"` // ionosphere divergence of the arc trend [m/s] (null for arcs below min_arc epochs)
+}
+
+func (d *Derived) GpsTime() float64 {
+	return float64(d.Week)*604800 + float64(d.ToW)
+}
+
+// Correlator settings of the diagnostics
+type TrackingOptions struct {
+	Spacing float64 // early (and late) to prompt correlator spacing [chips]
+	TInt    float64 // coherent integration time of the correlators [s]
+	Window  int     // successive epochs of a prn in the nwpr C/No estimate (blocks of nwprBlock)
+	MaxGap  float64 // longest time between epochs of a window (or an arc) [s]
+	AdrSign float64 // +1 when the adr grows with the range, -1 when it grows with the doppler
+	CmcJump float64 // largest code-minus-carrier change between epochs of an arc, larger is a cycle slip [m]
+	MinArc  int     // fewest epochs of an arc whose multipath is estimated
+}
+
+// Correlator settings of the generator
+var DefaultTrackingOptions = TrackingOptions{
+	Spacing: 0.5, TInt: 0.02, Window: 20, MaxGap: 5, AdrSign: 1, CmcJump: 10, MinArc: 10,
+}
+
+// Epochs per narrow-band sum of the nwpr estimate
+const nwprBlock = 5
+
+// Parse "spacing=", "t_int=", "cno_window=", "adr_sign=", "cmc_jump=" and "min_arc=" over the defaults
+func ParseTrackingOptions(q url.Values) (TrackingOptions, error) {
+	o := DefaultTrackingOptions
+	var err1, err2, err3, err4, err5, err6 error
+	if s := q.Get("spacing"); s != "" {
+		o.Spacing, err1 = strconv.ParseFloat(s, 64)
+	}
+	if s := q.Get("t_int"); s != "" {
+		o.TInt, err2 = strconv.ParseFloat(s, 64)
+	}
+	if s := q.Get("cno_window"); s != "" {
+		o.Window, err3 = strconv.Atoi(s)
+	}
+	if s := q.Get("adr_sign"); s != "" {
+		o.AdrSign, err4 = strconv.ParseFloat(s, 64)
+	}
+	if s := q.Get("cmc_jump"); s != "" {
+		o.CmcJump, err5 = strconv.ParseFloat(s, 64)
+	}
+	if s := q.Get("min_arc"); s != "" {
+		o.MinArc, err6 = strconv.Atoi(s)
+	}
+	switch {
+	case err1 != nil || !(o.Spacing > 0 && o.Spacing < 1):
+		return o, fmt.Errorf("spacing must be between 0 and 1 chip, got '%s'", q.Get("spacing"))
+	case err2 != nil || !(o.TInt > 0 && o.TInt <= 1):
+		return o, fmt.Errorf("t_int must be between 0 and 1 s, got '%s'", q.Get("t_int"))
+	case err3 != nil || o.Window < nwprBlock:
+		return o, fmt.Errorf("cno_window must be an integer of at least %d epochs, got '%s'", nwprBlock, q.Get("cno_window"))
+	case err4 != nil || (o.AdrSign != 1 && o.AdrSign != -1):
+		return o, fmt.Errorf("adr_sign must be 1 or -1, got '%s'", q.Get("adr_sign"))
+	case err5 != nil || !(o.CmcJump > 0):
+		return o, fmt.Errorf("cmc_jump must be a positive distance [m], got '%s'", q.Get("cmc_jump"))
+	case err6 != nil || o.MinArc < 3:
+		return o, fmt.Errorf("min_arc must be an integer of at least 3 epochs, got '%s'", q.Get("min_arc"))
+	}
+	return o, nil
+}
+
+// Normalized early-minus-late envelope discriminator, the code phase error for an early/late spacing [chips]
+func (sv *Satellite) DllDiscriminator(spacing float64) float64 {
+	e := math.Hypot(float64(sv.IE), float64(sv.QE))
+	l := math.Hypot(float64(sv.IL), float64(sv.QL))
+	if e+l == 0 {
+		return 0
+	}
+	return (1 - spacing) * (e - l) / (e + l)
+}
+
+// Costas (data insensitive) discriminator, the carrier phase error [rad]
+func (sv *Satellite) PllDiscriminator() float64 {
+	if sv.IP == 0 {
+		return 0
+	}
+	return math.Atan(float64(sv.QP) / float64(sv.IP))
+}
+
+// Phase lock indicator, cos of twice the carrier phase error (1 when the receiver reports no prompt correlator)
+func (sv *Satellite) PhaseLock() float64 {
+	ip2, qp2 := float64(sv.IP)*float64(sv.IP), float64(sv.QP)*float64(sv.QP)
+	if ip2+qp2 == 0 {
+		return 1
+	}
+	return (ip2 - qp2) / (ip2 + qp2)
+}
+
+// Tracking diagnostics of time ordered satellite records, the nwpr C/No of a record uses the prompt correlators of
+// the window of successive epochs of its prn that ends with it and its multipath the whole carrier arc it is in
+func Derive(items []Satellite, opts TrackingOptions) []Derived {
+	out := make([]Derived, len(items))
+	history := make(map[uint8][]*Satellite)
+	for i := range items {
+		sv := &items[i]
+		h := history[sv.PRN]
+		if n := len(h); n > 0 && (sv.GpsTime() <= h[n-1].GpsTime() || sv.GpsTime()-h[n-1].GpsTime() > opts.MaxGap) {
+			h = h[:0] // a gap (or time going back) restarts the window
+		}
+		h = append(h, sv)
+		if len(h) > opts.Window {
+			h = h[1:]
+		}
+		history[sv.PRN] = h
+
+		out[i] = Derived{
+			Sequence: sv.Sequence, Week: sv.Week, ToW: sv.ToW, PRN: sv.PRN, Health: sv.Health,
+			X: sv.X, Y: sv.Y, Z: sv.Z, Vx: sv.Vx, Vy: sv.Vy, Vz: sv.Vz,
+			Doppler: sv.Doppler, PSR: sv.PSR, ADR: sv.ADR, Azimuth: sv.Azimuth, Elevation: sv.Elevation, CNo: sv.CNo,
+			IE: sv.IE, IP: sv.IP, IL: sv.IL, QE: sv.QE, QP: sv.QP, QL: sv.QL,
+			DLL: sv.DllDiscriminator(opts.Spacing),
+			PLL: sv.PllDiscriminator(),
+			PLI: sv.PhaseLock(),
+		}
+		if len(h) == opts.Window {
+			if cno, ok := nwpr(h, opts.TInt); ok {
+				out[i].CNoNWPR = &cno
+			}
+		}
+	}
+
+	// code-minus-carrier of every arc
+	n := make(map[uint8]int)
+	for _, idx := range splitArcs(items, opts) {
+		prn := items[idx[0]].PRN
+		n[prn]++
+		for k, p := range fitArc(items, idx, opts) {
+			d := &out[idx[k]]
+			d.Arc, d.CMC, d.Multipath, d.IonoRate = n[prn], &p.CMC, p.Multipath, p.IonoRate
+		}
+	}
+	return out
+}
+
+// Narrow/wide-band power ratio C/No [dB-Hz]: the mean ratio mu of the coherent (narrow-band) to the incoherent
+// (wide-band) power of blocks of M prompt samples gives C/No = (mu - 1) / (T (M - mu)), the data bits are wiped
+// by the sign of IP (false when the ratio is outside (1, M))
+func nwpr(window []*Satellite, t_int float64) (float64, bool) {
+	const m = nwprBlock
+	k := len(window) / m
+	if k == 0 {
+		return 0, false
+	}
+	mu := 0.0
+	for b := range k {
+		var sum_i, sum_q, wbp float64
+		for _, sv := range window[len(window)-(b+1)*m : len(window)-b*m] {
+			i, q := math.Abs(float64(sv.IP)), float64(sv.QP)
+			if sv.IP < 0 {
+				q = -q
+			}
+			sum_i += i
+			sum_q += q
+			wbp += i*i + q*q
+		}
+		if wbp == 0 {
+			return 0, false
+		}
+		mu += (sum_i*sum_i + sum_q*sum_q) / wbp / float64(k)
+	}
+	if mu <= 1 || mu >= m {
+		return 0, false
+	}
+	return 10 * math.Log10((mu-1)/(t_int*(m-mu))), true
+}
@@ -0,0 +1,139 @@
+package satellite
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/url"
+	"testing"
+)
+
+func TestDiscriminators(t *testing.T) {
+	tests := []struct {
+		name          string
+		sv            Satellite
+		dll, pll, pli float64
+	}{
+		// |early| = 5, |late| = 3: (1 - 0.5) * 2 / 8
+		{"early", Satellite{IE: 3, QE: 4, IP: 1000, IL: 0, QL: 3}, 0.125, 0, 1},
+		{"late", Satellite{IE: 3, IP: 1000, IL: 3, QL: 4}, -0.125, 0, 1},
+		{"centered", Satellite{IE: 600, QE: 10, IP: 1000, IL: 600, QL: -10}, 0, 0, 1},
+		// 45 deg phase error on either data bit, the costas discriminator ignores the sign of ip
+		{"45 deg", Satellite{IP: 1000, QP: 1000}, 0, math.Pi / 4, 0},
+		{"45 deg, inverted bit", Satellite{IP: -1000, QP: -1000}, 0, math.Pi / 4, 0},
+		{"-30 deg", Satellite{IP: float32(math.Cos(math.Pi / 6)), QP: -0.5}, 0, -math.Pi / 6, 0.5},
+		{"90 deg", Satellite{QP: 1000}, 0, 0, -1},
+		{"no correlators", Satellite{}, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		dll, pll, pli := tt.sv.DllDiscriminator(0.5), tt.sv.PllDiscriminator(), tt.sv.PhaseLock()
+		if math.Abs(dll-tt.dll) > 1e-6 || math.Abs(pll-tt.pll) > 1e-6 || math.Abs(pli-tt.pli) > 1e-6 {
+			t.Errorf("%s: dll %g pll %g pli %g, want %g, %g and %g", tt.name, dll, pll, pli, tt.dll, tt.pll, tt.pli)
+		}
+	}
+}
+
+// Prompt correlators of one prn every 20 ms at a C/No [dB-Hz], the signal amplitude over unit noise of each arm
+// (C/No T = A^2 / 2) with random data bits
+func testPrompt(r *rand.Rand, prn uint8, cno float64, n int) []Satellite {
+	a := math.Sqrt(2 * math.Pow(10, cno/10) * 0.02)
+	svs := make([]Satellite, n)
+	for k := range svs {
+		bit := 1.0
+		if r.IntN(2) == 0 {
+			bit = -1
+		}
+		svs[k] = Satellite{Week: 2300, ToW: float32(1000 + 0.02*float64(k)), PRN: prn,
+			IP: float32(bit*a + r.NormFloat64()), QP: float32(r.NormFloat64())}
+	}
+	return svs
+}
+
+func TestNwpr(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, cno := range []float64{30, 35, 40, 45} {
+		// mean of independent windows of 20 epochs
+		svs := testPrompt(r, 0, cno, 20*200)
+		mean, n := 0.0, 0
+		for w := 0; w+20 <= len(svs); w += 20 {
+			window := make([]*Satellite, 20)
+			for k := range window {
+				window[k] = &svs[w+k]
+			}
+			if est, ok := nwpr(window, 0.02); ok {
+				mean += est
+				n++
+			}
+		}
+		mean /= float64(n)
+		if n < 190 || math.Abs(mean-cno) > 0.5 {
+			t.Errorf("C/No %g dB-Hz: %d estimates with a mean of %.2f dB-Hz", cno, n, mean)
+		}
+	}
+
+	// a noiseless signal has no wide-band excess, and a window shorter than a block has no sum
+	clean := []*Satellite{{IP: 100}, {IP: -100}, {IP: 100}, {IP: 100}, {IP: -100}}
+	if est, ok := nwpr(clean, 0.02); ok {
+		t.Errorf("estimate %g of a noiseless signal", est)
+	}
+	if _, ok := nwpr(clean[:4], 0.02); ok {
+		t.Error("estimate of a window shorter than a block")
+	}
+}
+
+func TestDeriveNwprWindow(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	opts := DefaultTrackingOptions
+
+	// two prns interleaved, the second one with a 10 s outage after its 30th epoch
+	a, b := testPrompt(r, 1, 40, 60), testPrompt(r, 2, 40, 60)
+	var items []Satellite
+	for k := range a {
+		if k >= 30 {
+			b[k].ToW += 10
+		}
+		items = append(items, a[k], b[k])
+	}
+	out := Derive(items, opts)
+	if len(out) != len(items) {
+		t.Fatalf("%d derived records of %d", len(out), len(items))
+	}
+	for i, d := range out {
+		k := i / 2
+		full := k >= opts.Window-1
+		if d.PRN == 2 && k >= 30 {
+			full = k-30 >= opts.Window-1 // the outage restarts the window
+		}
+		if (d.CNoNWPR != nil) != full {
+			t.Errorf("prn %d epoch %d: cno_nwpr %v, want it set %v", d.PRN, k, d.CNoNWPR, full)
+		}
+		if d.CNoNWPR != nil && math.Abs(*d.CNoNWPR-40) > 5 {
+			t.Errorf("prn %d epoch %d: cno_nwpr %g, want about 40", d.PRN, k, *d.CNoNWPR)
+		}
+		if d.PLI != items[i].PhaseLock() || d.PLL != items[i].PllDiscriminator() || d.ToW != items[i].ToW {
+			t.Errorf("prn %d epoch %d: derived %+v of %+v", d.PRN, k, d, items[i])
+		}
+	}
+}
+
+func TestParseTrackingOptions(t *testing.T) {
+	tests := []struct {
+		query string
+		ok    bool
+	}{
+		{"", true},
+		{"spacing=0.25&t_int=0.001&cno_window=50&adr_sign=-1&cmc_jump=2&min_arc=3", true},
+		{"spacing=1", false},
+		{"t_int=0", false},
+		{"cno_window=4", false},
+		{"adr_sign=0", false},
+		{"cmc_jump=-1", false},
+		{"min_arc=2", false},
+		{"min_arc=x", false},
+	}
+	for _, tt := range tests {
+		q, _ := url.ParseQuery(tt.query)
+		if _, err := ParseTrackingOptions(q); (err == nil) != tt.ok {
+			t.Errorf("%q: error %v", tt.query, err)
+		}
+	}
+}
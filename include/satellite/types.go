@@ -54,3 +54,27 @@ func (sv *Satellite) Args() []any {
 		&sv.QL,
 	}
 }
+
+// Seconds since the gps epoch
+func (sv *Satellite) GpsTime() float64 {
+	return float64(sv.Week)*604800 + float64(sv.ToW)
+}
+
+// Constellation name of a prn (same numbering as the gui)
+func Constellation(prn uint8) string {
+	switch {
+	case prn <= 31:
+		return "gps"
+	case prn >= 32 && prn <= 61:
+		return "galileo"
+	case prn >= 64 && prn <= 95:
+		return "glonass"
+	case prn >= 96 && prn <= 158:
+		return "beidou"
+	case prn >= 159 && prn <= 163:
+		return "qzss"
+	case prn >= 164 && prn <= 170:
+		return "navic"
+	}
+	return "unknown"
+}
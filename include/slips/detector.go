@@ -0,0 +1,94 @@
+package slips
+
+import (
+	"math"
+	"sync"
+
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+// Detector settings
+type Options struct {
+	Threshold     float64 // largest accepted difference of the adr change and the doppler prediction [cycles]
+	LockThreshold float64 // smallest phase lock indicator of a locked loop (cos of twice the phase error)
+	MaxGap        float64 // longest time between epochs the adr is checked across [s]
+	AdrSign       float64 // +1 when the adr grows with the range (adr rate = -doppler), -1 when it grows with the doppler
+}
+
+// Detector settings without settings
+var DefaultOptions = Options{Threshold: 1.0, LockThreshold: 0.5, MaxGap: 5.0, AdrSign: 1.0}
+
+// Previous measurement of a satellite
+type track struct {
+	t       float64
+	doppler float64
+	adr     float64
+	locked  bool
+}
+
+// Cycle slip and loss of lock detector over time ordered measurements of every satellite
+type Detector struct {
+	opts   Options
+	mu     sync.Mutex
+	tracks map[uint8]track
+}
+
+func NewDetector(opts Options) *Detector {
+	return &Detector{opts: opts, tracks: make(map[uint8]track)}
+}
+
+// Check the measurements of one epoch, returning the events of its satellites
+func (d *Detector) Detect(svs []satellite.Satellite) []Slip {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var events []Slip
+	for i := range svs {
+		events = append(events, d.check(&svs[i])...)
+	}
+	return events
+}
+
+// Check the next measurement of a satellite against its previous one: the adr change over the gap against the
+// trapezoidal integral of the doppler, and the prompt correlator phase against the lock region
+func (d *Detector) check(sv *satellite.Satellite) []Slip {
+	var events []Slip
+	t := gpsTime(sv.Week, float64(sv.ToW))
+	cur := track{t: t, doppler: float64(sv.Doppler), adr: float64(sv.ADR), locked: true}
+	ev := Slip{Week: sv.Week, ToW: float64(sv.ToW), PRN: sv.PRN, CNo: float64(sv.CNo)}
+	prev, seen := d.tracks[sv.PRN]
+	if seen && t <= prev.t {
+		seen = false // time went back (EX: a restarted replay), the track starts over
+	}
+
+	// 1. phase lock (always locked when the receiver reports no correlators)
+	ev.Lock = sv.PhaseLock()
+	cur.locked = ev.Lock >= d.opts.LockThreshold
+	if !cur.locked && (!seen || prev.locked) {
+		e := ev
+		e.Kind = KindLockLoss
+		events = append(events, e)
+	}
+
+	// 2. adr continuity (a zero adr is not tracking the carrier, a long gap restarts the check)
+	dt := t - prev.t
+	if seen && cur.adr != 0 && prev.adr != 0 && dt <= d.opts.MaxGap {
+		predicted := -d.opts.AdrSign * (prev.doppler + cur.doppler) / 2 * dt
+		ev.Cycles = (cur.adr - prev.adr) - predicted
+
+		// the adr is stored in single precision, so large values are only known to their spacing
+		tol := d.opts.Threshold + ulp32(cur.adr) + ulp32(prev.adr)
+		if math.Abs(ev.Cycles) > tol {
+			e := ev
+			e.Kind = KindSlip
+			events = append(events, e)
+		}
+	}
+	d.tracks[sv.PRN] = cur
+	return events
+}
+
+// Spacing of single precision values around x
+func ulp32(x float64) float64 {
+	f := float32(math.Abs(x))
+	return float64(math.Nextafter32(f, float32(math.Inf(1))) - f)
+}
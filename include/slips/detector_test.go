@@ -0,0 +1,181 @@
+package slips
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+// Epoch k of a satellite 1 s apart whose doppler ramps by 2 Hz/s, with the adr integrated from it (adr rate =
+// -doppler) and locked prompt correlators
+func testEpoch(prn uint8, k int) satellite.Satellite {
+	t := float64(k)
+	return satellite.Satellite{Week: 2300, ToW: float32(1000 + t), PRN: prn, CNo: 45,
+		Doppler: float32(1000 + 2*t), ADR: float32(1e5 - (1000*t + t*t)), IP: 5000, QP: 50}
+}
+
+// Events of the epochs of one satellite, fed in order
+func detect(d *Detector, svs []satellite.Satellite) []Slip {
+	var events []Slip
+	for i := range svs {
+		events = append(events, d.Detect(svs[i:i+1])...)
+	}
+	return events
+}
+
+func TestDetectorClean(t *testing.T) {
+	d := NewDetector(DefaultOptions)
+	for k := 0; k < 20; k++ {
+		if events := d.Detect([]satellite.Satellite{testEpoch(0, k), testEpoch(1, k)}); len(events) != 0 {
+			t.Fatalf("epoch %d: %+v on a clean track", k, events)
+		}
+	}
+
+	// no correlators count as locked, and a zero adr is not checked
+	d = NewDetector(DefaultOptions)
+	var svs []satellite.Satellite
+	for k := 0; k < 5; k++ {
+		sv := testEpoch(3, k)
+		sv.IP, sv.QP = 0, 0
+		if k == 2 {
+			sv.ADR = 0
+		}
+		svs = append(svs, sv)
+	}
+	if events := detect(d, svs); len(events) != 0 {
+		t.Errorf("%+v without correlators or adr", events)
+	}
+}
+
+func TestDetectorSlip(t *testing.T) {
+	tests := []struct {
+		cycles float64
+		slip   bool
+	}{
+		{1.5, true},
+		{-7, true},
+		{100, true},
+		{0.5, false},
+	}
+	for _, tt := range tests {
+		var svs []satellite.Satellite
+		for k := 0; k < 10; k++ {
+			sv := testEpoch(0, k)
+			if k >= 5 {
+				sv.ADR += float32(tt.cycles) // the jump stays in the later adr, only its epoch slips
+			}
+			svs = append(svs, sv)
+		}
+		events := detect(NewDetector(DefaultOptions), svs)
+		if !tt.slip {
+			if len(events) != 0 {
+				t.Errorf("%g cycles: %+v below the threshold", tt.cycles, events)
+			}
+			continue
+		}
+		if len(events) != 1 {
+			t.Fatalf("%g cycles: %d events, want 1", tt.cycles, len(events))
+		}
+		if e := events[0]; e.Kind != KindSlip || e.ToW != 1005 || e.PRN != 0 || math.Abs(e.Cycles-tt.cycles) > 0.05 ||
+			e.CNo != 45 {
+			t.Errorf("%g cycles: %+v, want a slip at 1005", tt.cycles, e)
+		}
+	}
+
+	// a receiver whose adr grows with the doppler sees the same track as a slip every epoch unless the sign says so
+	var svs []satellite.Satellite
+	for k := 0; k < 5; k++ {
+		sv := testEpoch(0, k)
+		sv.ADR = 2e5 - sv.ADR
+		svs = append(svs, sv)
+	}
+	if events := detect(NewDetector(DefaultOptions), svs); len(events) != 4 {
+		t.Errorf("%d events with the wrong adr sign, want 4", len(events))
+	}
+	opts := DefaultOptions
+	opts.AdrSign = -1
+	if events := detect(NewDetector(opts), svs); len(events) != 0 {
+		t.Errorf("%+v with the adr sign of the receiver", events)
+	}
+}
+
+func TestDetectorLockLoss(t *testing.T) {
+	// the prompt phase rotated 60 deg (lock indicator cos(120 deg) = -0.5) for epochs 3-6 and 9, 25 deg (0.64) at 8
+	var svs []satellite.Satellite
+	for k := 0; k < 12; k++ {
+		sv := testEpoch(0, k)
+		switch k {
+		case 3, 4, 5, 6, 9:
+			sv.IP, sv.QP = float32(5000*math.Cos(math.Pi/3)), float32(5000*math.Sin(math.Pi/3))
+		case 8:
+			sv.IP, sv.QP = float32(5000*math.Cos(25*math.Pi/180)), float32(5000*math.Sin(25*math.Pi/180))
+		}
+		svs = append(svs, sv)
+	}
+	events := detect(NewDetector(DefaultOptions), svs)
+
+	// one event when the lock is lost, not one per unlocked epoch, and again after the lock returns
+	if len(events) != 2 {
+		t.Fatalf("%d events, want 2: %+v", len(events), events)
+	}
+	for i, tow := range []float64{1003, 1009} {
+		if e := events[i]; e.Kind != KindLockLoss || e.ToW != tow || math.Abs(e.Lock+0.5) > 1e-3 {
+			t.Errorf("event %d: %+v, want a lock loss at %g", i, e, tow)
+		}
+	}
+
+	// a track that starts unlocked reports it once
+	svs = svs[3:7]
+	if events := detect(NewDetector(DefaultOptions), svs); len(events) != 1 || events[0].ToW != 1003 {
+		t.Errorf("%+v, want one lock loss at 1003", events)
+	}
+}
+
+func TestDetectorGap(t *testing.T) {
+	tests := []struct {
+		name  string
+		gap   int // epochs missing before the jump
+		slips int
+	}{
+		{"within the gap", 3, 1},
+		{"longer than the gap", 6, 0},
+	}
+	for _, tt := range tests {
+		var svs []satellite.Satellite
+		for k := 0; k < 12; k++ {
+			if k > 3 && k <= 3+tt.gap {
+				continue
+			}
+			sv := testEpoch(0, k)
+			if k > 3 {
+				sv.ADR += 10
+			}
+			svs = append(svs, sv)
+		}
+		if events := detect(NewDetector(DefaultOptions), svs); len(events) != tt.slips {
+			t.Errorf("%s: %d slips, want %d", tt.name, len(events), tt.slips)
+		}
+	}
+}
+
+func TestDetectorTimeBack(t *testing.T) {
+	// a restarted replay goes back to the first epoch with a different adr, the track starts over
+	d := NewDetector(DefaultOptions)
+	var svs []satellite.Satellite
+	for k := 0; k < 5; k++ {
+		svs = append(svs, testEpoch(0, k))
+	}
+	restart := testEpoch(0, 0)
+	restart.ADR += 1000
+	svs = append(svs, restart, testEpoch(0, 1))
+	svs[6].ADR += 1000
+	if events := detect(d, svs); len(events) != 0 {
+		t.Errorf("%+v after going back in time", events)
+	}
+
+	// a repeated epoch restarts it too
+	if events := detect(d, []satellite.Satellite{testEpoch(0, 1), testEpoch(0, 2)}); len(events) != 0 {
+		t.Errorf("%+v after a repeated epoch", events)
+	}
+}
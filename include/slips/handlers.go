@@ -0,0 +1,161 @@
+package slips
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/logging"
+)
+
+var logger = logging.For("slips")
+
+type Handler struct {
+	service Service
+	opts    Options
+}
+
+// The detector settings are the defaults of scans
+func NewHttpHandler(s Service, opts Options) *Handler {
+	return &Handler{service: s, opts: opts}
+}
+
+// Handle http slip read request, the events of a span per satellite (EX: "/slips/read?week=2300&tow=0&prn=5")
+func (h *Handler) Read(w http.ResponseWriter, r *http.Request) {
+	span, err := parseSpan(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.service.ReadSlips(r.Context(), span)
+	if err != nil {
+		handleError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	// events are ordered by satellite, then time
+	out := []Satellite{}
+	for _, e := range data {
+		if len(out) == 0 || out[len(out)-1].PRN != e.PRN {
+			out = append(out, Satellite{PRN: e.PRN, Events: []Slip{}})
+		}
+		sat := &out[len(out)-1]
+		switch e.Kind {
+		case KindSlip:
+			sat.Slips++
+		case KindLockLoss:
+			sat.LockLoss++
+		}
+		sat.Events = append(sat.Events, e)
+	}
+
+	if err := encoder.Write(w, r, http.StatusOK, out); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+}
+
+// Handle http slip scan request, the detector run over the stored measurements of a span with its events stored
+// (EX: "/slips/scan?week=2300&tow=0&threshold=0.5")
+func (h *Handler) Scan(w http.ResponseWriter, r *http.Request) {
+	span, err := parseSpan(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	opts := h.opts
+	query := r.URL.Query()
+	for name, v := range map[string]*float64{
+		"threshold": &opts.Threshold, "lock_threshold": &opts.LockThreshold, "max_gap": &opts.MaxGap,
+	} {
+		if s := query.Get(name); s != "" {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+				handleError(w, r, fmt.Errorf("invalid %s '%s'", name, s), http.StatusBadRequest)
+				return
+			}
+			*v = f
+		}
+	}
+
+	n, err := h.service.ScanSlips(r.Context(), span, opts)
+	if err != nil {
+		handleError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	// send success status
+	encoder.WriteText(w, http.StatusOK, fmt.Sprintf("Success (%d events)", n))
+}
+
+// Handle http slip clear request (default every event)
+func (h *Handler) Clear(w http.ResponseWriter, r *http.Request) {
+	span, err := parseSpan(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	n, err := h.service.DeleteSlips(r.Context(), span)
+	if err != nil {
+		handleError(w, r, err, http.StatusExpectationFailed)
+		return
+	}
+
+	// send success status
+	encoder.WriteText(w, http.StatusOK, fmt.Sprintf("Success (%d events)", n))
+}
+
+// Reusable error handler
+func handleError(w http.ResponseWriter, r *http.Request, e error, c int) {
+	logger.WarnContext(r.Context(), "Slips error!", "status", c, "error", e)
+	http.Error(w, e.Error(), c)
+}
+
+func parseSpan(r *http.Request) (Span, error) {
+	query := r.URL.Query()
+	start_week, err1 := parseWeek(query.Get("week"), 0)
+	start_tow, err2 := parseSeconds(query.Get("tow"), 0)
+	end_week, err3 := parseWeek(query.Get("end_week"), 0xFFFF)
+	end_tow, err4 := parseSeconds(query.Get("end_tow"), 604800)
+	prn := -1
+	var err5 error
+	if s := query.Get("prn"); s != "" {
+		v, err := strconv.ParseUint(s, 10, 8)
+		if err != nil {
+			err5 = fmt.Errorf("invalid prn '%s'", s)
+		}
+		prn = int(v)
+	}
+	if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
+		return Span{}, err
+	}
+	return Span{StartWeek: uint16(start_week), StartToW: start_tow, EndWeek: uint16(end_week), EndToW: end_tow,
+		PRN: prn}, nil
+}
+
+func parseWeek(s string, fallback uint64) (uint64, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid week '%s'", s)
+	}
+	return v, nil
+}
+
+func parseSeconds(s string, fallback float64) (float64, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("invalid time '%s'", s)
+	}
+	return v, nil
+}
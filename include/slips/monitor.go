@@ -0,0 +1,58 @@
+package slips
+
+import (
+	"context"
+
+	"github.com/sturdivant20/sturdr-api/include/metrics"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+	"github.com/sturdivant20/sturdr-api/include/telemetry"
+)
+
+// Telemetry service that checks the carrier phase of created epochs once they are stored
+type detectingService struct {
+	telemetry.Service
+	store    Service
+	detector *Detector
+}
+
+// Check the adr and prompt correlators of every created epoch against the previous epoch, storing the events
+// (epochs must arrive in time order, every source shares the detector)
+func WithSlipDetection(s telemetry.Service, store Service, opts Options) telemetry.Service {
+	return &detectingService{Service: s, store: store, detector: NewDetector(opts)}
+}
+
+func (s *detectingService) CreateTelemetry(ctx context.Context, data telemetry.Telemetry) error {
+	if err := s.Service.CreateTelemetry(ctx, data); err != nil {
+		return err
+	}
+	s.detect(ctx, data.Satellites)
+	return nil
+}
+
+func (s *detectingService) CreateTelemetryBatch(ctx context.Context, data []telemetry.Telemetry) error {
+	if err := s.Service.CreateTelemetryBatch(ctx, data); err != nil {
+		return err
+	}
+	for i := range data {
+		s.detect(ctx, data[i].Satellites)
+	}
+	return nil
+}
+
+func (s *detectingService) detect(ctx context.Context, svs []satellite.Satellite) {
+	events := s.detector.Detect(svs)
+	if len(events) == 0 {
+		return
+	}
+	for i := range events {
+		e := &events[i]
+		metrics.CarrierEvents.WithLabelValues(e.Kind).Inc()
+		logger.InfoContext(ctx, "Carrier phase event!", "kind", e.Kind, "prn", e.PRN, "week", e.Week, "tow", e.ToW,
+			"cycles", e.Cycles, "lock", e.Lock, "cno", e.CNo)
+	}
+
+	// the telemetry is already stored, so a failed event write is only logged
+	if err := s.store.CreateSlips(ctx, events); err != nil {
+		logger.ErrorContext(ctx, "Error storing carrier phase events!", "events", len(events), "error", err)
+	}
+}
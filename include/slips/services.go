@@ -0,0 +1,150 @@
+package slips
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/metrics"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+// Time span (and satellite) of a slip request
+type Span struct {
+	StartWeek uint16
+	StartToW  float64
+	EndWeek   uint16
+	EndToW    float64
+	PRN       int // -1 for every satellite
+}
+
+type Service interface {
+	CreateSlips(ctx context.Context, data []Slip) error
+	ReadSlips(ctx context.Context, span Span) ([]Slip, error)
+	DeleteSlips(ctx context.Context, span Span) (int64, error)
+	ScanSlips(ctx context.Context, span Span, opts Options) (int, error)
+}
+
+type SlipService struct {
+	db                *sql.DB
+	CreateStmt        *sql.Stmt
+	ReadSpanStmt      *sql.Stmt
+	DeleteSpanStmt    *sql.Stmt
+	ReadSatelliteStmt *sql.Stmt
+}
+
+// Initialize/create the slip table
+func NewSlipService(db *sql.DB, sql_fname string) Service {
+	// --- read sql commands from file ---
+	data, err := os.ReadFile(sql_fname)
+	if err != nil {
+		logger.Error("Error reading SQL file!", "file", sql_fname, "error", err)
+		os.Exit(1)
+	}
+	cmd := strings.Split(string(data), ";")
+
+	// --- create table ---
+	statement := bindStatement(db, strings.TrimSpace(cmd[0]), "make_slip_table")
+	statement.Exec()
+	statement = bindStatement(db, cmd[1], "create_slip_index")
+	statement.Exec()
+	logger.Info("Created database table ...", "table", "slips")
+
+	// --- bind statements ---
+	return &SlipService{
+		db:                db,
+		CreateStmt:        bindStatement(db, strings.TrimSpace(cmd[2]), "create_slip"),
+		ReadSpanStmt:      bindStatement(db, strings.TrimSpace(cmd[3]), "read_slip_span"),
+		DeleteSpanStmt:    bindStatement(db, strings.TrimSpace(cmd[4]), "delete_slip_span"),
+		ReadSatelliteStmt: bindStatement(db, strings.TrimSpace(cmd[5]), "read_slip_satellite_span")}
+}
+
+// Store events in a single transaction (an event of the same satellite, time and kind replaces it)
+func (s *SlipService) CreateSlips(ctx context.Context, data []Slip) error {
+	defer metrics.ObserveStatement("create_slip", time.Now())
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := tx.StmtContext(ctx, s.CreateStmt)
+	for i := range data {
+		if _, err = stmt.ExecContext(ctx, data[i].Args()...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Read the events of a span, ordered by satellite and time
+func (s *SlipService) ReadSlips(ctx context.Context, span Span) ([]Slip, error) {
+	defer metrics.ObserveStatement("read_slip_span", time.Now())
+	rows, err := s.ReadSpanStmt.QueryContext(ctx, span.StartWeek, span.StartToW, span.EndWeek, span.EndToW, span.PRN)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Slip{}
+	for rows.Next() {
+		var e Slip
+		if err := rows.Scan(e.Args()...); err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+	}
+	return items, rows.Err()
+}
+
+// Delete the events of a span, returning the number deleted
+func (s *SlipService) DeleteSlips(ctx context.Context, span Span) (int64, error) {
+	defer metrics.ObserveStatement("delete_slip_span", time.Now())
+	res, err := s.DeleteSpanStmt.ExecContext(ctx, span.StartWeek, span.StartToW, span.EndWeek, span.EndToW, span.PRN)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Run a detector over the stored measurements of a span and store its events, returning the number found
+func (s *SlipService) ScanSlips(ctx context.Context, span Span, opts Options) (int, error) {
+	defer metrics.ObserveStatement("read_slip_satellite_span", time.Now())
+	rows, err := s.ReadSatelliteStmt.QueryContext(ctx, span.StartWeek, span.StartToW, span.EndWeek, span.EndToW, span.PRN)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	d := NewDetector(opts)
+	var events []Slip
+	for rows.Next() {
+		var sv satellite.Satellite
+		if err := rows.Scan(&sv.Week, &sv.ToW, &sv.PRN, &sv.Doppler, &sv.ADR, &sv.CNo, &sv.IP, &sv.QP); err != nil {
+			return 0, err
+		}
+		events = append(events, d.check(&sv)...)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	rows.Close()
+	return len(events), s.CreateSlips(ctx, events)
+}
+
+// Seconds since the gps epoch
+func gpsTime(week uint16, tow float64) float64 {
+	return float64(week)*604800 + tow
+}
+
+// bindStatement
+func bindStatement(db *sql.DB, cmd string, name string) *sql.Stmt {
+	create_stmt, err := db.Prepare(cmd)
+	if err != nil {
+		logger.Error("Error preparing statement!", "statement", name, "error", err)
+		os.Exit(1)
+	}
+	return create_stmt
+}
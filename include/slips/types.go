@@ -0,0 +1,43 @@
+package slips
+
+// Carrier phase events
+const (
+	KindSlip     = "slip"      // the adr moved differently than the doppler predicts
+	KindLockLoss = "lock_loss" // the prompt correlator phase left the lock region
+)
+
+// Carrier phase event of one satellite
+type Slip struct {
+	Week   uint16  `json:"week"`
+	ToW    float64 `json:"tow"`
+	PRN    uint8   `json:"prn"`
+	Kind   string  `json:"kind"`
+	Cycles float64 `json:"cycles"` // adr change minus the doppler prediction [cycles]
+	Lock   float64 `json:"lock"`   // phase lock indicator (IP^2-QP^2)/(IP^2+QP^2), 1 when locked
+	CNo    float64 `json:"cno"`    // [dB-Hz]
+}
+
+func (s *Slip) Args() []any {
+	return []any{
+		&s.Week,
+		&s.ToW,
+		&s.PRN,
+		&s.Kind,
+		&s.Cycles,
+		&s.Lock,
+		&s.CNo,
+	}
+}
+
+// Seconds since the gps epoch
+func (s *Slip) GpsTime() float64 {
+	return gpsTime(s.Week, s.ToW)
+}
+
+// Carrier phase events of one satellite
+type Satellite struct {
+	PRN      uint8  `json:"prn"`
+	Slips    int    `json:"slips"`
+	LockLoss int    `json:"lock_loss"`
+	Events   []Slip `json:"events"` // in time order
+}
@@ -0,0 +1,163 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/sturdivant20/sturdr-api/include/decimate"
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/logging"
+)
+
+var logger = logging.For("stats")
+
+type Handler struct {
+	service Service
+}
+
+func NewHttpHandler(s Service) *Handler {
+	return &Handler{service: s}
+}
+
+// Handle http stats request (EX: "/stats?week=2300&tow=0&end_week=2300&end_tow=3600")
+func (h *Handler) Read(w http.ResponseWriter, r *http.Request) {
+	// span from week/tow to end_week/end_tow (default every stored epoch)
+	span, err := parseSpan(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// summarize the span
+	st, err := h.service.ReadStats(r.Context(), span)
+	if err != nil {
+		handleError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	if st.Navigation.Epochs == 0 && len(st.Satellites) == 0 {
+		handleError(w, r, errors.New("no telemetry in the requested span"), http.StatusNotFound)
+		return
+	}
+
+	if err := encoder.Write(w, r, http.StatusOK, st); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+}
+
+// Handle http accuracy request against a surveyed reference (EX: "/accuracy?lla=32.5865,-85.4944,200&week=2300&tow=0")
+func (h *Handler) Accuracy(w http.ResponseWriter, r *http.Request) {
+	span, err := parseSpan(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// reference as "lla=" (deg, deg, m) or "ecef=" (m)
+	ref, err := parseReference(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// downsample the per epoch errors ("every=", "bucket=" or "points="), the summary uses every epoch
+	opts, err := decimate.ParseOptions(r.URL.Query(), "horizontal")
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	acc, err := h.service.ReadAccuracy(r.Context(), span, ref)
+	if err != nil {
+		handleError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	if acc.Epochs == 0 {
+		handleError(w, r, errors.New("no navigation in the requested span"), http.StatusNotFound)
+		return
+	}
+	if acc.Errors, err = decimate.Series(acc.Errors, (*PositionError).GpsTime, opts); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := encoder.Write(w, r, http.StatusOK, acc); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+}
+
+// Reusable error handler
+func handleError(w http.ResponseWriter, r *http.Request, e error, c int) {
+	logger.WarnContext(r.Context(), "Stats error!", "status", c, "error", e)
+	http.Error(w, e.Error(), c)
+}
+
+func parseSpan(r *http.Request) (Span, error) {
+	query := r.URL.Query()
+	start_week, err1 := parseWeek(query.Get("week"), 0)
+	start_tow, err2 := parseSeconds(query.Get("tow"), 0)
+	end_week, err3 := parseWeek(query.Get("end_week"), 0xFFFF)
+	end_tow, err4 := parseSeconds(query.Get("end_tow"), 604800)
+	gap, err5 := parseSeconds(query.Get("gap"), 10)
+	if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
+		return Span{}, err
+	}
+	return Span{StartWeek: uint16(start_week), StartToW: start_tow, EndWeek: uint16(end_week), EndToW: end_tow, Gap: gap}, nil
+}
+
+func parseReference(r *http.Request) (Reference, error) {
+	query := r.URL.Query()
+	s_lla, s_ecef := query.Get("lla"), query.Get("ecef")
+	switch {
+	case s_lla != "" && s_ecef != "":
+		return Reference{}, errors.New("only one of lla and ecef can be given")
+	case s_lla != "":
+		v, err := geodesy.ParsePoint(s_lla)
+		if err != nil || math.Abs(v[0]) > 90 || math.Abs(v[1]) > 180 {
+			return Reference{}, fmt.Errorf("lla must be 'latitude,longitude,altitude' in degrees and meters, got '%s'", s_lla)
+		}
+		xyz := geodesy.Lla2Ecef([3]float64{v[0] * geodesy.Deg2Rad, v[1] * geodesy.Deg2Rad, v[2]})
+		return Reference{Latitude: v[0], Longitude: v[1], Altitude: v[2], X: xyz[0], Y: xyz[1], Z: xyz[2]}, nil
+	case s_ecef != "":
+		v, err := geodesy.ParsePoint(s_ecef)
+		if err != nil {
+			return Reference{}, fmt.Errorf("ecef must be 'x,y,z' in meters, got '%s'", s_ecef)
+		}
+		lla := geodesy.Ecef2Lla(v)
+		return Reference{
+			Latitude:  lla[0] * geodesy.Rad2Deg,
+			Longitude: lla[1] * geodesy.Rad2Deg,
+			Altitude:  lla[2],
+			X:         v[0],
+			Y:         v[1],
+			Z:         v[2]}, nil
+	}
+	return Reference{}, errors.New("a reference position is required as lla= or ecef=")
+}
+
+func parseWeek(s string, fallback uint64) (uint64, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid week '%s'", s)
+	}
+	return v, nil
+}
+
+func parseSeconds(s string, fallback float64) (float64, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("invalid time '%s'", s)
+	}
+	return v, nil
+}
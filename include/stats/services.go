@@ -0,0 +1,251 @@
+package stats
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/analysis"
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/metrics"
+)
+
+// Time span of a summary
+type Span struct {
+	StartWeek uint16
+	StartToW  float64
+	EndWeek   uint16
+	EndToW    float64
+	Gap       float64 // longest gap inside a tracking arc [s]
+}
+
+type Service interface {
+	ReadStats(ctx context.Context, span Span) (Stats, error)
+	ReadAccuracy(ctx context.Context, span Span, ref Reference) (Accuracy, error)
+}
+
+type StatsService struct {
+	db                 *sql.DB
+	ReadNavigationStmt *sql.Stmt
+	ReadSatelliteStmt  *sql.Stmt
+	ReadPositionStmt   *sql.Stmt
+}
+
+// Bind the statements that stream a time span of navigation and satellite rows
+func NewStatsService(db *sql.DB, sql_fname string) Service {
+	// --- read sql commands from file ---
+	data, err := os.ReadFile(sql_fname)
+	if err != nil {
+		logger.Error("Error reading SQL file!", "file", sql_fname, "error", err)
+		os.Exit(1)
+	}
+	cmd := strings.Split(string(data), ";")
+
+	// --- bind statements ---
+	return &StatsService{
+		db:                 db,
+		ReadNavigationStmt: bindStatement(db, strings.TrimSpace(cmd[0]), "read_navigation_span"),
+		ReadSatelliteStmt:  bindStatement(db, strings.TrimSpace(cmd[1]), "read_satellite_span"),
+		ReadPositionStmt:   bindStatement(db, strings.TrimSpace(cmd[2]), "read_position_span")}
+}
+
+// Summarize a time span, streaming over the rows (memory does not grow with the length of the span)
+func (s *StatsService) ReadStats(ctx context.Context, span Span) (Stats, error) {
+	var st Stats
+	if err := s.navigationStats(ctx, span, &st); err != nil {
+		return Stats{}, err
+	}
+	var err error
+	if st.Satellites, err = s.satelliteStats(ctx, span); err != nil {
+		return Stats{}, err
+	}
+	return st, nil
+}
+
+func (s *StatsService) navigationStats(ctx context.Context, span Span, st *Stats) error {
+	defer metrics.ObserveStatement("read_navigation_span", time.Now())
+	rows, err := s.ReadNavigationStmt.QueryContext(ctx, span.StartWeek, span.StartToW, span.EndWeek, span.EndToW)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	lat, lon, alt := analysis.NewRunning(), analysis.NewRunning(), analysis.NewRunning()
+	speed, vertical := analysis.NewRunning(), analysis.NewRunning()
+	n_sat, pdop, hdop, vdop := analysis.NewRunning(), analysis.NewRunning(), analysis.NewRunning(), analysis.NewRunning()
+	var epochs uint64
+	for rows.Next() {
+		var week uint16
+		var tow, sats, la, lo, h, vn, ve, vd, p, hd, vv float64
+		if err := rows.Scan(&week, &tow, &sats, &la, &lo, &h, &vn, &ve, &vd, &p, &hd, &vv); err != nil {
+			return err
+		}
+		if epochs == 0 {
+			st.StartWeek, st.StartToW = week, tow
+		}
+		st.EndWeek, st.EndToW = week, tow
+		epochs++
+
+		lat.Add(la)
+		lon.Add(lo)
+		alt.Add(h)
+		speed.Add(math.Hypot(vn, ve))
+		vertical.Add(-vd)
+		n_sat.Add(sats)
+		pdop.Add(p)
+		hdop.Add(hd)
+		vdop.Add(vv)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// spread of the position in meters about its mean
+	re, rn := geodesy.Radii(lat.Mean() * geodesy.Deg2Rad)
+	north := lat.Std() * geodesy.Deg2Rad * (rn + alt.Mean())
+	east := lon.Std() * geodesy.Deg2Rad * (re + alt.Mean()) * math.Cos(lat.Mean()*geodesy.Deg2Rad)
+
+	st.Navigation = NavigationStats{
+		Epochs:        epochs,
+		Duration:      gpsTime(st.EndWeek, st.EndToW) - gpsTime(st.StartWeek, st.StartToW),
+		Latitude:      lat.Summary(),
+		Longitude:     lon.Summary(),
+		Altitude:      alt.Summary(),
+		Spread:        Spread{North: north, East: east, Up: alt.Std(), Horizontal: math.Hypot(north, east)},
+		Speed:         speed.Summary(),
+		VerticalSpeed: vertical.Summary(),
+		NSat:          n_sat.Summary(),
+		PDOP:          pdop.Summary(),
+		HDOP:          hdop.Summary(),
+		VDOP:          vdop.Summary(),
+	}
+	return nil
+}
+
+// Running statistics of the satellite being read
+type satelliteRun struct {
+	stats     SatelliteStats
+	cno       *analysis.Running
+	elevation *analysis.Running
+	last      float64 // gps time of the previous row [s]
+}
+
+func (s *StatsService) satelliteStats(ctx context.Context, span Span) ([]SatelliteStats, error) {
+	defer metrics.ObserveStatement("read_satellite_span", time.Now())
+	rows, err := s.ReadSatelliteStmt.QueryContext(ctx, span.StartWeek, span.StartToW, span.EndWeek, span.EndToW)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// rows arrive by prn, then time
+	items := []SatelliteStats{}
+	var run *satelliteRun
+	flush := func() {
+		if run != nil {
+			run.stats.CNo = run.cno.Summary()
+			run.stats.Elevation = run.elevation.Summary()
+			items = append(items, run.stats)
+		}
+	}
+	for rows.Next() {
+		var prn, health uint8
+		var week uint16
+		var tow, elevation, cno float64
+		if err := rows.Scan(&prn, &week, &tow, &health, &elevation, &cno); err != nil {
+			return nil, err
+		}
+		t := gpsTime(week, tow)
+
+		if run == nil || run.stats.PRN != prn {
+			flush()
+			run = &satelliteRun{
+				stats:     SatelliteStats{PRN: prn, Arcs: 1, Health: health},
+				cno:       analysis.NewRunning(),
+				elevation: analysis.NewRunning(),
+				last:      t,
+			}
+		} else if dt := t - run.last; dt > span.Gap {
+			run.stats.Arcs++
+		} else {
+			run.stats.Tracked += dt
+		}
+		if health != run.stats.Health {
+			run.stats.HealthChanges++
+			run.stats.Health = health
+		}
+		run.stats.Epochs++
+		run.last = t
+		run.cno.Add(cno)
+		run.elevation.Add(elevation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return items, nil
+}
+
+// Position errors of a span against a reference, in the east/north/up frame of the reference
+func (s *StatsService) ReadAccuracy(ctx context.Context, span Span, ref Reference) (Accuracy, error) {
+	defer metrics.ObserveStatement("read_position_span", time.Now())
+	rows, err := s.ReadPositionStmt.QueryContext(ctx, span.StartWeek, span.StartToW, span.EndWeek, span.EndToW)
+	if err != nil {
+		return Accuracy{}, err
+	}
+	defer rows.Close()
+
+	origin := geodesy.NewOriginEcef([3]float64{ref.X, ref.Y, ref.Z})
+	acc := Accuracy{Reference: ref, Errors: []PositionError{}}
+	var errs []analysis.ENU
+	for rows.Next() {
+		var week uint16
+		var tow, lat, lon, alt float64
+		if err := rows.Scan(&week, &tow, &lat, &lon, &alt); err != nil {
+			return Accuracy{}, err
+		}
+
+		// position in the local level frame of the reference
+		enu := origin.Lla2Enu([3]float64{lat * geodesy.Deg2Rad, lon * geodesy.Deg2Rad, alt})
+		e := analysis.ENU{East: enu[0], North: enu[1], Up: enu[2]}
+		errs = append(errs, e)
+		acc.Errors = append(acc.Errors, PositionError{
+			Week:       week,
+			ToW:        tow,
+			East:       e.East,
+			North:      e.North,
+			Up:         e.Up,
+			Horizontal: math.Hypot(e.East, e.North),
+			Vertical:   e.Up,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return Accuracy{}, err
+	}
+
+	if n := len(acc.Errors); n > 0 {
+		acc.StartWeek, acc.StartToW = acc.Errors[0].Week, acc.Errors[0].ToW
+		acc.EndWeek, acc.EndToW = acc.Errors[n-1].Week, acc.Errors[n-1].ToW
+	}
+	acc.Epochs = uint64(len(acc.Errors))
+	acc.Summary = analysis.PositionAccuracy(errs)
+	return acc, nil
+}
+
+// Seconds since the gps epoch
+func gpsTime(week uint16, tow float64) float64 {
+	return float64(week)*604800 + tow
+}
+
+// bindStatement
+func bindStatement(db *sql.DB, cmd string, name string) *sql.Stmt {
+	create_stmt, err := db.Prepare(cmd)
+	if err != nil {
+		logger.Error("Error preparing statement!", "statement", name, "error", err)
+		os.Exit(1)
+	}
+	return create_stmt
+}
@@ -0,0 +1,128 @@
+package stats
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/sturdivant20/sturdr-api/include/navigation"
+	"github.com/sturdivant20/sturdr-api/include/satellite"
+)
+
+// Tables of the repository's sql files on an empty database of the test
+func newTestDatabase(t *testing.T) (*sql.DB, navigation.Service, satellite.Service) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "sturdr.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, navigation.NewNavigationService(db, "../../config/sql/navigation.sql"),
+		satellite.NewSatelliteService(db, "../../config/sql/satellite.sql")
+}
+
+func TestSatelliteStats(t *testing.T) {
+	db, s_navigation, s_satellite := newTestDatabase(t)
+	ctx := context.Background()
+
+	// prn 3 tracked for 0-2 s, lost, then tracked again for 10-11 s with one unhealthy epoch, prn 7 seen once, and a
+	// prn 3 epoch after the span
+	records := []struct {
+		prn    uint8
+		tow    float32
+		health uint8
+		cno    float32
+	}{
+		{3, 0, 0, 40}, {3, 1, 0, 42}, {3, 2, 0, 44},
+		{3, 10, 1, 30}, {3, 11, 0, 34},
+		{7, 5, 1, 25},
+		{3, 200, 0, 50},
+	}
+	for i, r := range records {
+		seq := uint64(i + 1)
+		if err := s_navigation.CreateNavigation(ctx, navigation.Navigation{Sequence: seq, Week: 2300, ToW: r.tow}); err != nil {
+			t.Fatal(err)
+		}
+		sv := satellite.Satellite{Sequence: seq, Week: 2300, ToW: r.tow, PRN: r.prn, Health: r.health, CNo: r.cno,
+			Elevation: 10 * float32(i)}
+		if err := s_satellite.CreateSatellite(ctx, sv); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := NewStatsService(db, "../../config/sql/stats.sql")
+	st, err := s.ReadStats(ctx, Span{StartWeek: 2300, StartToW: 0, EndWeek: 2300, EndToW: 100, Gap: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st.Satellites) != 2 {
+		t.Fatalf("got %d satellites, want 2", len(st.Satellites))
+	}
+
+	tests := []struct {
+		got, want SatelliteStats
+	}{
+		{st.Satellites[0], SatelliteStats{PRN: 3, Epochs: 5, Tracked: 3, Arcs: 2, HealthChanges: 2, Health: 0}},
+		{st.Satellites[1], SatelliteStats{PRN: 7, Epochs: 1, Tracked: 0, Arcs: 1, HealthChanges: 0, Health: 1}},
+	}
+	for _, tt := range tests {
+		g, w := tt.got, tt.want
+		if g.PRN != w.PRN || g.Epochs != w.Epochs || g.Tracked != w.Tracked || g.Arcs != w.Arcs ||
+			g.HealthChanges != w.HealthChanges || g.Health != w.Health {
+			t.Errorf("prn %d: epochs %d tracked %g arcs %d health changes %d health %d, want %+v",
+				g.PRN, g.Epochs, g.Tracked, g.Arcs, g.HealthChanges, g.Health, w)
+		}
+	}
+	if c := st.Satellites[0].CNo; c.Count != 5 || c.Mean != 38 || c.Min != 30 || c.Max != 44 {
+		t.Errorf("prn 3 cno %+v, want 5 values with mean 38 from 30 to 44", c)
+	}
+
+	// the navigation epochs of the span
+	if n := st.Navigation; n.Epochs != 6 || n.Duration != 11 || st.StartToW != 0 || st.EndToW != 11 {
+		t.Errorf("navigation epochs %d over %g s (%g to %g), want 6 over 11 s", n.Epochs, n.Duration, st.StartToW,
+			st.EndToW)
+	}
+}
+
+func TestSatelliteStatsGap(t *testing.T) {
+	db, s_navigation, s_satellite := newTestDatabase(t)
+	ctx := context.Background()
+
+	// one prn every second with a 5 s outage
+	tows := []float32{0, 1, 2, 3, 8, 9}
+	for i, tow := range tows {
+		seq := uint64(i + 1)
+		if err := s_navigation.CreateNavigation(ctx, navigation.Navigation{Sequence: seq, Week: 2300, ToW: tow}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s_satellite.CreateSatellite(ctx, satellite.Satellite{Sequence: seq, Week: 2300, ToW: tow, PRN: 0}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the outage splits the arc unless the gap allows it
+	s := NewStatsService(db, "../../config/sql/stats.sql")
+	tests := []struct {
+		gap     float64
+		arcs    uint32
+		tracked float64
+	}{
+		{1.5, 2, 4},
+		{5, 1, 9},
+	}
+	for _, tt := range tests {
+		st, err := s.ReadStats(ctx, Span{StartWeek: 2300, EndWeek: 2300, EndToW: 100, Gap: tt.gap})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sv := st.Satellites[0]
+		if sv.PRN != 0 || sv.Arcs != tt.arcs || math.Abs(sv.Tracked-tt.tracked) > 1e-9 {
+			t.Errorf("gap %g: prn %d arcs %d tracked %g, want prn 0, %d arcs and %g s", tt.gap, sv.PRN, sv.Arcs,
+				sv.Tracked, tt.arcs, tt.tracked)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package stats
+
+import "github.com/sturdivant20/sturdr-api/include/analysis"
+
+// Summary of the telemetry stored in a time span
+type Stats struct {
+	StartWeek  uint16           `json:"start_week"` // first and last epoch in the span
+	StartToW   float64          `json:"start_tow"`
+	EndWeek    uint16           `json:"end_week"`
+	EndToW     float64          `json:"end_tow"`
+	Navigation NavigationStats  `json:"navigation"`
+	Satellites []SatelliteStats `json:"satellites"`
+}
+
+// Navigation solution statistics
+type NavigationStats struct {
+	Epochs        uint64           `json:"epochs"`
+	Duration      float64          `json:"duration"` // [s]
+	Latitude      analysis.Summary `json:"latitude"` // [deg]
+	Longitude     analysis.Summary `json:"longitude"`
+	Altitude      analysis.Summary `json:"altitude"` // [m]
+	Spread        Spread           `json:"spread"`
+	Speed         analysis.Summary `json:"speed"`          // horizontal [m/s]
+	VerticalSpeed analysis.Summary `json:"vertical_speed"` // up [m/s]
+	NSat          analysis.Summary `json:"n_sat"`
+	PDOP          analysis.Summary `json:"pdop"`
+	HDOP          analysis.Summary `json:"hdop"`
+	VDOP          analysis.Summary `json:"vdop"`
+}
+
+// Standard deviation of the position about its mean [m]
+type Spread struct {
+	North      float64 `json:"north"`
+	East       float64 `json:"east"`
+	Up         float64 `json:"up"`
+	Horizontal float64 `json:"horizontal"` // drms
+}
+
+// Tracking statistics of one satellite
+type SatelliteStats struct {
+	PRN           uint8            `json:"prn"`
+	Epochs        uint64           `json:"epochs"`
+	Tracked       float64          `json:"tracked"` // time covered by the arcs [s]
+	Arcs          uint32           `json:"arcs"`    // continuous tracking arcs (split by gaps longer than "gap=")
+	HealthChanges uint32           `json:"health_changes"`
+	Health        uint8            `json:"health"` // last health flag
+	CNo           analysis.Summary `json:"cno"`    // [dB-Hz]
+	Elevation     analysis.Summary `json:"elevation"`
+}
+
+// Positioning accuracy against a surveyed reference
+type Accuracy struct {
+	StartWeek uint16            `json:"start_week"`
+	StartToW  float64           `json:"start_tow"`
+	EndWeek   uint16            `json:"end_week"`
+	EndToW    float64           `json:"end_tow"`
+	Epochs    uint64            `json:"epochs"`
+	Reference Reference         `json:"reference"`
+	Summary   analysis.Accuracy `json:"summary"`
+	Errors    []PositionError   `json:"errors"` // per epoch (downsampled when asked)
+}
+
+// Surveyed antenna position
+type Reference struct {
+	Latitude  float64 `json:"latitude"` // [deg]
+	Longitude float64 `json:"longitude"`
+	Altitude  float64 `json:"altitude"` // [m]
+	X         float64 `json:"x"`        // ecef [m]
+	Y         float64 `json:"y"`
+	Z         float64 `json:"z"`
+}
+
+// Position error of one epoch in the local level frame of the reference [m]
+type PositionError struct {
+	Week       uint16  `json:"week"`
+	ToW        float64 `json:"tow"`
+	East       float64 `json:"east"`
+	North      float64 `json:"north"`
+	Up         float64 `json:"up"`
+	Horizontal float64 `json:"horizontal"`
+	Vertical   float64 `json:"vertical"` // signed up error
+}
+
+// Seconds since the gps epoch
+func (e *PositionError) GpsTime() float64 {
+	return gpsTime(e.Week, e.ToW)
+}
@@ -0,0 +1,79 @@
+package telemetry
+
+import (
+	"sync"
+	"time"
+)
+
+// Broker fans out live telemetry epochs to every stream subscriber
+type Broker struct {
+	mu        sync.RWMutex
+	subs      map[chan Telemetry]struct{}
+	latest    Telemetry
+	published time.Time
+	closed    bool
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[chan Telemetry]struct{})}
+}
+
+// Subscribe to published telemetry, the returned function must be called to unsubscribe
+func (b *Broker) Subscribe() (<-chan Telemetry, func()) {
+	ch := make(chan Telemetry, 16)
+	b.mu.Lock()
+	if b.closed {
+		close(ch)
+	} else {
+		b.subs[ch] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+		})
+	}
+}
+
+// Publish an epoch to all subscribers (slow subscribers miss epochs instead of blocking ingest)
+func (b *Broker) Publish(data Telemetry) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.latest = data
+	b.published = time.Now()
+	for ch := range b.subs {
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+}
+
+// Latest published epoch and the time it was published
+func (b *Broker) Latest() (Telemetry, time.Time, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.latest, b.published, !b.published.IsZero()
+}
+
+// Close every subscription (so open streams end when the server shuts down)
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for ch := range b.subs {
+		close(ch)
+		delete(b.subs, ch)
+	}
+}
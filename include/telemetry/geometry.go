@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/sturdivant20/sturdr-api/include/gnss"
+	"github.com/sturdivant20/sturdr-api/include/metrics"
+)
+
+// Service that recomputes the satellite geometry of created epochs before they are stored
+type geometryService struct {
+	Service
+	opts gnss.CheckOptions
+}
+
+// Check the receiver azimuth, elevation and dop of every created epoch against the satellite positions, logging
+// mismatches (and filling in zero values when opts.Fill is set)
+func WithGeometryCheck(s Service, opts gnss.CheckOptions) Service {
+	return &geometryService{Service: s, opts: opts}
+}
+
+func (s *geometryService) CreateTelemetry(ctx context.Context, data Telemetry) error {
+	s.check(ctx, &data)
+	return s.Service.CreateTelemetry(ctx, data)
+}
+
+func (s *geometryService) CreateTelemetryBatch(ctx context.Context, data []Telemetry) error {
+	for i := range data {
+		s.check(ctx, &data[i])
+	}
+	return s.Service.CreateTelemetryBatch(ctx, data)
+}
+
+func (s *geometryService) check(ctx context.Context, data *Telemetry) {
+	c := gnss.CheckGeometry(&data.Navigation, data.Satellites, s.opts)
+	if c.Filled > 0 {
+		metrics.GeometryFilled.Add(float64(c.Filled))
+	}
+	if c.Mismatches == 0 {
+		return
+	}
+
+	// prns whose azimuth or elevation disagree
+	var prns []int
+	for _, sc := range c.Satellites {
+		if sc.Azimuth.Mismatch {
+			metrics.GeometryMismatches.WithLabelValues("azimuth").Inc()
+		}
+		if sc.Elevation.Mismatch {
+			metrics.GeometryMismatches.WithLabelValues("elevation").Inc()
+		}
+		if sc.Azimuth.Mismatch || sc.Elevation.Mismatch {
+			prns = append(prns, int(sc.PRN))
+		}
+	}
+	for name, v := range map[string]gnss.Value{"pdop": c.PDOP, "hdop": c.HDOP, "vdop": c.VDOP} {
+		if v.Mismatch {
+			metrics.GeometryMismatches.WithLabelValues(name).Inc()
+		}
+	}
+	logger.WarnContext(ctx, "Geometry mismatch!", "sequence", c.Sequence, "week", c.Week, "tow", c.ToW,
+		"mismatches", c.Mismatches, "prns", prns, "pdop", c.PDOP.Reported, "pdop_computed", c.PDOP.Computed)
+}
@@ -1,32 +1,65 @@
 package telemetry
 
 import (
-	"encoding/binary"
-	"log"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
+	"github.com/sturdivant20/sturdr-api/include/decimate"
 	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/gnss"
+	"github.com/sturdivant20/sturdr-api/include/logging"
+	"github.com/sturdivant20/sturdr-api/include/navigation"
 )
 
+var logger = logging.For("telemetry")
+
 type Handler struct {
 	service Service
+	replay  Service // nil without a replay controller
+	broker  *Broker
+}
+
+func NewHttpHandler(s Service, b *Broker) *Handler {
+	return &Handler{service: s, broker: b}
+}
+
+// Attach the read-only replay view that serves reads with "source=replay"
+func (h *Handler) WithReplay(s Service) *Handler {
+	h.replay = s
+	return h
 }
 
-func NewHttpHandler(s Service) *Handler {
-	return &Handler{service: s}
+// Service of a read, the stored data or the replayed epochs played so far ("source=database" or "source=replay")
+func (h *Handler) reader(r *http.Request) (Service, error) {
+	switch source := r.URL.Query().Get("source"); source {
+	case "", "database":
+		return h.service, nil
+	case "replay":
+		if h.replay != nil {
+			return h.replay, nil
+		}
+		fallthrough
+	default:
+		return nil, fmt.Errorf("source must be 'database' or 'replay', got '%s'", source)
+	}
 }
 
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	var data Telemetry
-	if err := readRequest(r, &data); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := encoder.Read(r, &data); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
 		return
 	}
 
 	// create telemetry using service
-	if err := h.service.createTelemetry(r.Context(), data); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := h.service.CreateTelemetry(r.Context(), data); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
@@ -34,20 +67,278 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	encoder.WriteText(w, http.StatusOK, "Success")
 }
 
+// Handle http create telemetry batch request (an array of epochs stored in one transaction)
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) {
+	var data []Telemetry
+	if err := encoder.Read(r, &data); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+
+	// create telemetry using service
+	if err := h.service.CreateTelemetryBatch(r.Context(), data); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// send success status
+	encoder.WriteText(w, http.StatusOK, fmt.Sprintf("Success (%d epochs)", len(data)))
+}
+
 // Handle http read specific telemetry json request
 func (h *Handler) Read(w http.ResponseWriter, r *http.Request) {
 	// request telemetry by gps week and tow
 	week, tow, do_query := parseQuery(r)
 
+	// navigation positions in "frame=" ("lla", "ecef", or "enu" relative to "origin=")
+	frame, err := navigation.ParseFrame(r.URL.Query())
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// limit the columns to "fields=" (EX: "navigation.tow,satellites.prn,satellites.cno"), named as in the frame
+	fields, err := encoder.ParseFields(r.URL.Query().Get("fields"), frameRecord(frame))
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// downsample long histories ("every=", "bucket=" or "points=")
+	opts, err := decimate.ParseOptions(r.URL.Query(), "navigation."+frame.Height())
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if opts.Bucket > 0 {
+		handleError(w, r, fmt.Errorf("bucket cannot aggregate telemetry, read navigation or satellites instead"),
+			http.StatusBadRequest)
+		return
+	}
+	read_fields := fields
+	if frame.Converts() {
+		// every navigation column is read for the conversion (satellites only when selected)
+		read_fields = nil
+		if _, with_sats := fields.Sub("satellites"); fields != nil && !with_sats {
+			read_fields, _ = encoder.ParseFields("navigation", Telemetry{})
+		}
+	} else if fields != nil && opts.Enabled() {
+		// the times (and the preserved field) are read even when they are not sent
+		spec := r.URL.Query().Get("fields") + ",navigation.week,navigation.tow," + opts.By
+		if read_fields, err = encoder.ParseFields(spec, Telemetry{}); err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	}
+
 	// read queried telemetry from table
-	data, err := h.service.readTelemetry(r.Context(), week, tow, do_query)
+	service, err := h.reader(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	data, err := service.ReadTelemetry(r.Context(), week, tow, do_query, read_fields)
+	if err != nil {
+		handleError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	// epochs sent as they are stored are framed by n_sat in binary, one that can't be does not fail the whole read
+	if c, err := encoder.ResponseCodec(r); err == nil && c.ContentType() == "application/octet-stream" &&
+		fields == nil && !frame.Converts() {
+		data = framable(r.Context(), data)
+	}
+
+	out, err := convertFrame(frame, data, opts)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := encoder.Write(w, r, http.StatusOK, fields.Project(out)); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+}
+
+// Handle http geometry check request, the receiver azimuth/elevation and dop against the values recomputed from the
+// satellite positions (EX: "/telemetry/geometry?week=2300&tow=0&azel_tolerance=0.5")
+func (h *Handler) Geometry(w http.ResponseWriter, r *http.Request) {
+	week, tow, do_query := parseQuery(r)
+	opts := gnss.DefaultCheckOptions
+	var err1, err2 error
+	if s := r.URL.Query().Get("azel_tolerance"); s != "" {
+		opts.AzElTolerance, err1 = strconv.ParseFloat(s, 64)
+	}
+	if s := r.URL.Query().Get("dop_tolerance"); s != "" {
+		opts.DopTolerance, err2 = strconv.ParseFloat(s, 64)
+	}
+	if err1 != nil || err2 != nil || !(opts.AzElTolerance >= 0) || !(opts.DopTolerance >= 0) {
+		handleError(w, r, fmt.Errorf("tolerances must be non-negative numbers"), http.StatusBadRequest)
+		return
+	}
+
+	service, err := h.reader(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	data, err := service.ReadTelemetry(r.Context(), week, tow, do_query, nil)
+	if err != nil {
+		handleError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	checks := make([]gnss.Check, len(data))
+	for i := range data {
+		checks[i] = gnss.CheckGeometry(&data[i].Navigation, data[i].Satellites, opts)
+	}
+	if err := encoder.Write(w, r, http.StatusOK, checks); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+}
+
+// Handle http residual request, the pseudorange and doppler of stored epochs against the ranges predicted from the
+// satellite and navigation positions/velocities (EX: "/telemetry/residuals?week=2300&tow=0&prn=5&mask=10")
+func (h *Handler) Residuals(w http.ResponseWriter, r *http.Request) {
+	week, tow, do_query := parseQuery(r)
+	query := r.URL.Query()
+	var opts gnss.ResidualOptions
+	var prn uint64
+	var err1, err2, err3 error
+	if s := query.Get("mask"); s != "" {
+		opts.Mask, err1 = strconv.ParseFloat(s, 64)
+	}
+	if s := query.Get("sagnac"); s != "" {
+		opts.Sagnac, err2 = strconv.ParseBool(s)
+	}
+	if s := query.Get("prn"); s != "" {
+		prn, err3 = strconv.ParseUint(s, 10, 8)
+	}
+	if err1 != nil || err2 != nil || err3 != nil || !(opts.Mask >= -90 && opts.Mask <= 90) {
+		handleError(w, r, fmt.Errorf("mask must be an elevation [deg], sagnac a boolean and prn a satellite number"),
+			http.StatusBadRequest)
+		return
+	}
+
+	// downsample every prn series ("every=", "bucket=" or "points="), the statistics use every epoch
+	dec, err := decimate.ParseOptions(query, "psr")
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	service, err := h.reader(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	data, err := service.ReadTelemetry(r.Context(), week, tow, do_query, nil)
+	if err != nil {
+		handleError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	// 1. residuals of every epoch with a fix (the clock uses every satellite, prn= only limits the output)
+	epochs := make([]gnss.EpochResiduals, 0, len(data))
+	for i := range data {
+		e, ok := gnss.EpochResidual(&data[i].Navigation, data[i].Satellites, opts)
+		if !ok {
+			continue
+		}
+		if query.Has("prn") {
+			e.Satellites = slices.DeleteFunc(e.Satellites, func(r gnss.Residual) bool { return r.PRN != uint8(prn) })
+		}
+		epochs = append(epochs, e)
+	}
+	res := gnss.ResidualSeries(epochs)
+	if len(res.Satellites) == 0 {
+		handleError(w, r, fmt.Errorf("no satellite measurements with a navigation fix in the requested epochs"),
+			http.StatusNotFound)
+		return
+	}
+
+	// 2. downsample the series
+	for i := range res.Satellites {
+		sat := &res.Satellites[i]
+		if sat.Series, err = decimate.Series(sat.Series, (*gnss.Residual).GpsTime, dec); err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	}
+	clock_dec := dec
+	clock_dec.By = "bias"
+	if res.Clock, err = decimate.Series(res.Clock, (*gnss.Clock).GpsTime, clock_dec); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := encoder.Write(w, r, http.StatusOK, res); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+}
+
+// Handle http integrity request, the raim test of stored epochs with the options of the live monitor given in the
+// query (EX: "/telemetry/integrity?week=2300&tow=0&sigma=3&hal=40&val=50")
+func (h *Handler) Integrity(w http.ResponseWriter, r *http.Request) {
+	week, tow, do_query := parseQuery(r)
+	query := r.URL.Query()
+	opts := gnss.DefaultIntegrityOptions
+	for name, v := range map[string]*float64{
+		"sigma": &opts.Sigma, "pfa": &opts.Pfa, "pmd": &opts.Pmd, "hal": &opts.HAL, "val": &opts.VAL, "mask": &opts.Mask,
+	} {
+		if s := query.Get(name); s != "" {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+				handleError(w, r, fmt.Errorf("invalid %s '%s'", name, s), http.StatusBadRequest)
+				return
+			}
+			*v = f
+		}
+	}
+	if !(opts.Sigma > 0) || !(opts.Pfa > 0 && opts.Pfa < 1) || !(opts.Pmd > 0 && opts.Pmd < 1) {
+		handleError(w, r, fmt.Errorf("sigma must be positive, pfa and pmd probabilities between 0 and 1"),
+			http.StatusBadRequest)
+		return
+	}
+
+	// downsample the per epoch results, the counts and summaries use every epoch
+	dec, err := decimate.ParseOptions(query, "hpl")
 	if err != nil {
-		handleError(w, err, http.StatusNotFound)
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if dec.Bucket > 0 {
+		handleError(w, r, fmt.Errorf("bucket cannot aggregate integrity results, use every or points instead"),
+			http.StatusBadRequest)
 		return
 	}
 
-	if err := writeResponse(w, r, data); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	service, err := h.reader(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	data, err := service.ReadTelemetry(r.Context(), week, tow, do_query, nil)
+	if err != nil {
+		handleError(w, r, err, http.StatusNotFound)
+		return
+	}
+
+	results := make([]gnss.Integrity, len(data))
+	for i := range data {
+		results[i] = gnss.CheckIntegrity(&data[i].Navigation, data[i].Satellites, opts)
+	}
+	rep := gnss.Report(results)
+	if rep.Results, err = decimate.Series(rep.Results, (*gnss.Integrity).GpsTime, dec); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := encoder.Write(w, r, http.StatusOK, rep); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
 		return
 	}
 }
@@ -57,20 +348,20 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	// request telemetry by id
 	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
 	if err != nil {
-		handleError(w, err, http.StatusBadRequest)
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	// decode incoming json data
 	var data Telemetry
-	if err := readRequest(r, &data); err != nil {
-		handleError(w, err, http.StatusBadRequest)
+	if err := encoder.Read(r, &data); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
 		return
 	}
 
 	// update held telemetry
-	if err := h.service.updateTelemetry(r.Context(), data, id); err != nil {
-		handleError(w, err, http.StatusExpectationFailed)
+	if err := h.service.UpdateTelemetry(r.Context(), data, id); err != nil {
+		handleError(w, r, err, http.StatusExpectationFailed)
 		return
 	}
 
@@ -83,13 +374,13 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	// request telemetry by id
 	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
 	if err != nil {
-		handleError(w, err, http.StatusBadRequest)
+		handleError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	// delete held telemetry
-	if err := h.service.deleteTelemetry(r.Context(), id); err != nil {
-		handleError(w, err, http.StatusExpectationFailed)
+	if err := h.service.DeleteTelemetry(r.Context(), id); err != nil {
+		handleError(w, r, err, http.StatusExpectationFailed)
 		return
 	}
 
@@ -97,56 +388,71 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	encoder.WriteText(w, http.StatusOK, "Success")
 }
 
-// Reusable error handler
-func handleError(w http.ResponseWriter, e error, c int) {
-	log.Printf("Telemetry error %d! %s", c, e.Error())
-	http.Error(w, e.Error(), c)
-}
+// Handle http telemetry stream request (server-sent events with one json epoch per event, or a
+// sequence of epochs in a delimited format when one is accepted, EX: "Accept: application/msgpack")
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	codec, err := encoder.ResponseCodec(r)
+	sse := err != nil || codec.ContentType() == "application/json"
+	if !sse && !encoder.Delimited(codec) {
+		handleError(w, r, fmt.Errorf("%w: '%s' cannot be streamed", encoder.ErrNotAcceptable, codec.ContentType()),
+			http.StatusNotAcceptable)
+		return
+	}
 
-// Reusable read function
-func readRequest(r *http.Request, data *Telemetry) error {
-	switch r.URL.Query().Get("format") {
-	case "binary":
-		if err := encoder.ReadBinary(r, data); err != nil {
-			return err
-		}
-	case "json":
-		fallthrough
-	default:
-		if err := encoder.ReadJson(r, data); err != nil {
-			return err
-		}
+	// streams outlive the server write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		handleError(w, r, err, http.StatusInternalServerError)
+		return
 	}
 
-	return nil
-}
+	ch, cancel := h.broker.Subscribe()
+	defer cancel()
+
+	if sse {
+		w.Header().Set("Content-Type", "text/event-stream")
+	} else {
+		w.Header().Set("Content-Type", codec.ContentType())
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
 
-// Reusable write function
-func writeResponse(w http.ResponseWriter, r *http.Request, data []Telemetry) error {
-	switch r.URL.Query().Get("format") {
-	case "binary":
-		// write binary data (because a slice is used, the "WriteBinary" function cannot be used)
-		w.Header().Set("Content-Type", "application/octet-stream")
-		w.WriteHeader(http.StatusOK)
-		for i := 0; i < len(data); i++ {
-			if err := binary.Write(w, binary.BigEndian, data[i].Navigation); err != nil {
-				return err
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data, ok := <-ch:
+			if !ok {
+				return
 			}
-			for _, sv := range data[i].Satellites {
-				if err := binary.Write(w, binary.BigEndian, sv); err != nil {
-					return err
-				}
+			if sse {
+				fmt.Fprint(w, "data: ")
+				err = json.NewEncoder(w).Encode(data)
+				fmt.Fprint(w, "\n")
+			} else {
+				err = codec.Encode(w, &data)
+			}
+			if errors.Is(err, ErrUnframed) {
+				// nothing of the epoch was written, the stream goes on with the next one
+				logger.WarnContext(r.Context(), "Skipped binary epoch!", "sequence", data.Navigation.Sequence, "error", err)
+				continue
+			}
+			if err != nil {
+				logger.WarnContext(r.Context(), "Telemetry stream error!", "error", err)
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
 			}
-		}
-	case "json":
-		fallthrough
-	default:
-		if err := encoder.WriteJson(w, http.StatusOK, data); err != nil {
-			return err
 		}
 	}
+}
 
-	return nil
+// Reusable error handler
+func handleError(w http.ResponseWriter, r *http.Request, e error, c int) {
+	logger.WarnContext(r.Context(), "Telemetry error!", "status", c, "error", e)
+	http.Error(w, e.Error(), c)
 }
 
 func parseQuery(r *http.Request) (uint16, float32, bool) {
@@ -0,0 +1,59 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/sturdivant20/sturdr-api/include/gnss"
+	"github.com/sturdivant20/sturdr-api/include/metrics"
+)
+
+// Service that tests the integrity of created epochs once they are stored
+type integrityService struct {
+	Service
+	opts gnss.IntegrityOptions
+}
+
+// Test the pseudorange residuals of every created epoch (raim), alerting when an epoch fails the test
+func WithIntegrityMonitor(s Service, opts gnss.IntegrityOptions) Service {
+	return &integrityService{Service: s, opts: opts}
+}
+
+func (s *integrityService) CreateTelemetry(ctx context.Context, data Telemetry) error {
+	if err := s.Service.CreateTelemetry(ctx, data); err != nil {
+		return err
+	}
+	s.monitor(ctx, &data)
+	return nil
+}
+
+func (s *integrityService) CreateTelemetryBatch(ctx context.Context, data []Telemetry) error {
+	if err := s.Service.CreateTelemetryBatch(ctx, data); err != nil {
+		return err
+	}
+	for i := range data {
+		s.monitor(ctx, &data[i])
+	}
+	return nil
+}
+
+func (s *integrityService) monitor(ctx context.Context, data *Telemetry) {
+	c := gnss.CheckIntegrity(&data.Navigation, data.Satellites, s.opts)
+	metrics.IntegrityEpochs.WithLabelValues(c.Status).Inc()
+	metrics.IntegrityProtectionLevel.WithLabelValues("horizontal").Set(c.HPL)
+	metrics.IntegrityProtectionLevel.WithLabelValues("vertical").Set(c.VPL)
+	if c.Available {
+		metrics.IntegrityAvailable.Set(1)
+	} else {
+		metrics.IntegrityAvailable.Set(0)
+	}
+	if !c.Failed() {
+		return
+	}
+
+	args := []any{"sequence", c.Sequence, "week", c.Week, "tow", c.ToW, "status", c.Status}
+	if c.Excluded != nil {
+		args = append(args, "excluded", *c.Excluded)
+	}
+	args = append(args, "statistic", c.Statistic, "threshold", c.Threshold, "hpl", c.HPL, "vpl", c.VPL)
+	logger.WarnContext(ctx, "Integrity alert!", args...)
+}
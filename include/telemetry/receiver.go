@@ -0,0 +1,35 @@
+package telemetry
+
+import (
+	"context"
+)
+
+// Service that reports the epochs a receiver creates once they are stored
+type receiverService struct {
+	Service
+	observe func(data *Telemetry)
+}
+
+// Call observe with every epoch created through the returned service (the http and grpc ingest and the generator, the
+// replay publishes without storing, so its epochs are not reported as receiver epochs)
+func WithReceiver(s Service, observe func(data *Telemetry)) Service {
+	return &receiverService{Service: s, observe: observe}
+}
+
+func (s *receiverService) CreateTelemetry(ctx context.Context, data Telemetry) error {
+	if err := s.Service.CreateTelemetry(ctx, data); err != nil {
+		return err
+	}
+	s.observe(&data)
+	return nil
+}
+
+func (s *receiverService) CreateTelemetryBatch(ctx context.Context, data []Telemetry) error {
+	if err := s.Service.CreateTelemetryBatch(ctx, data); err != nil {
+		return err
+	}
+	for i := range data {
+		s.observe(&data[i])
+	}
+	return nil
+}
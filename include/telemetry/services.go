@@ -3,25 +3,28 @@ package telemetry
 import (
 	"context"
 	"database/sql"
-	"errors"
-	"log"
-	"math"
+	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/metrics"
 	"github.com/sturdivant20/sturdr-api/include/navigation"
 	"github.com/sturdivant20/sturdr-api/include/satellite"
 )
 
 type Service interface {
-	createTelemetry(ctx context.Context, data Telemetry) error
-	readTelemetry(ctx context.Context, week uint16, tow float32, do_query bool) ([]Telemetry, error)
-	updateTelemetry(ctx context.Context, data Telemetry, sequence int64) error
-	deleteTelemetry(ctx context.Context, sequence int64) error
+	CreateTelemetry(ctx context.Context, data Telemetry) error
+	CreateTelemetryBatch(ctx context.Context, data []Telemetry) error
+	ReadTelemetry(ctx context.Context, week uint16, tow float32, do_query bool, fields *encoder.Fields) ([]Telemetry, error)
+	UpdateTelemetry(ctx context.Context, data Telemetry, sequence int64) error
+	DeleteTelemetry(ctx context.Context, sequence int64) error
 }
 
 type TelemetryService struct {
 	db                *sql.DB
+	broker            *Broker
 	CreateNavStmt     *sql.Stmt
 	CreateSatStmt     *sql.Stmt
 	ReadLatestNavStmt *sql.Stmt
@@ -32,121 +35,193 @@ type TelemetryService struct {
 	UpdateSatStmt     *sql.Stmt
 	DeleteNavStmt     *sql.Stmt
 	DeleteSatStmt     *sql.Stmt
+	reads             map[string]string // sql of the read statements (projections select fewer columns)
 }
 
-func NewTelemetryService(db *sql.DB, sql_fname string) Service {
+// Bind telemetry statements, created epochs are published to the broker (which may be nil)
+func NewTelemetryService(db *sql.DB, sql_fname string, broker *Broker) Service {
+	s, err := PrepareTelemetryService(db, sql_fname, broker)
+	if err != nil {
+		logger.Error("Error preparing telemetry statements!", "file", sql_fname, "error", err)
+		os.Exit(1)
+	}
+	return s
+}
+
+// Bind telemetry statements, returning an error instead of exiting (EX: a database recorded with another schema)
+func PrepareTelemetryService(db *sql.DB, sql_fname string, broker *Broker) (Service, error) {
 	// --- read sql commands from file ---
 	data, err := os.ReadFile(sql_fname)
 	if err != nil {
-		log.Fatalf("Error reading '%s': %s", sql_fname, err.Error())
+		return nil, err
 	}
 	cmd := strings.Split(string(data), ";")
 
 	// --- bind statements ---
+	names := []string{
+		"create_telemetry_nav", "create_telemetry_sv",
+		"read_latest_telemetry_nav", "read_queried_telemetry_nav", "read_latest_telemetry_sv", "read_queried_telemetry_sv",
+		"update_telemetry_nav", "update_telemetry_sv", "delete_telemetry_nav", "delete_telemetry_sv",
+	}
+	if len(cmd) < len(names) {
+		return nil, fmt.Errorf("'%s' has %d statements, expected %d", sql_fname, len(cmd), len(names))
+	}
+	stmts := make([]*sql.Stmt, len(names))
+	for i, name := range names {
+		if stmts[i], err = db.Prepare(strings.TrimSpace(cmd[i])); err != nil {
+			for _, stmt := range stmts[:i] {
+				stmt.Close()
+			}
+			return nil, fmt.Errorf("preparing %s: %w", name, err)
+		}
+	}
 	return &TelemetryService{
 		db:                db,
-		CreateNavStmt:     bindStatement(db, strings.TrimSpace(cmd[0]), "create_telemetry_nav"),
-		CreateSatStmt:     bindStatement(db, strings.TrimSpace(cmd[1]), "create_telemetry_sv"),
-		ReadLatestNavStmt: bindStatement(db, strings.TrimSpace(cmd[2]), "read_latest_telemetry_nav"),
-		ReadQueryNavStmt:  bindStatement(db, strings.TrimSpace(cmd[3]), "read_latest_telemetry_sv"),
-		ReadLatestSatStmt: bindStatement(db, strings.TrimSpace(cmd[4]), "read_queried_telemetry_nav"),
-		ReadQuerySatStmt:  bindStatement(db, strings.TrimSpace(cmd[5]), "read_queried_telemetry_sv"),
-		UpdateNavStmt:     bindStatement(db, strings.TrimSpace(cmd[6]), "update_telemetry_nav"),
-		UpdateSatStmt:     bindStatement(db, strings.TrimSpace(cmd[7]), "update_telemetry_sv"),
-		DeleteNavStmt:     bindStatement(db, strings.TrimSpace(cmd[8]), "delete_telemetry_nav"),
-		DeleteSatStmt:     bindStatement(db, strings.TrimSpace(cmd[9]), "delete_telemetry_sv")}
+		broker:            broker,
+		CreateNavStmt:     stmts[0],
+		CreateSatStmt:     stmts[1],
+		ReadLatestNavStmt: stmts[2],
+		ReadQueryNavStmt:  stmts[3],
+		ReadLatestSatStmt: stmts[4],
+		ReadQuerySatStmt:  stmts[5],
+		UpdateNavStmt:     stmts[6],
+		UpdateSatStmt:     stmts[7],
+		DeleteNavStmt:     stmts[8],
+		DeleteSatStmt:     stmts[9],
+		reads: map[string]string{
+			"read_latest_telemetry_nav":  strings.TrimSpace(cmd[2]),
+			"read_queried_telemetry_nav": strings.TrimSpace(cmd[3]),
+			"read_latest_telemetry_sv":   strings.TrimSpace(cmd[4]),
+			"read_queried_telemetry_sv":  strings.TrimSpace(cmd[5])}}, nil
 }
 
 // Add a telemetry to the table
-func (s *TelemetryService) createTelemetry(ctx context.Context, data Telemetry) error {
+func (s *TelemetryService) CreateTelemetry(ctx context.Context, data Telemetry) error {
+	return s.CreateTelemetryBatch(ctx, []Telemetry{data})
+}
+
+// Create several telemetry epochs in a single transaction (all or none are stored)
+func (s *TelemetryService) CreateTelemetryBatch(ctx context.Context, data []Telemetry) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	// 1. Create navigation post
-	stmt := tx.StmtContext(ctx, s.CreateNavStmt)
-	if _, err = stmt.ExecContext(ctx, data.Navigation.Args()...); err != nil {
-		return err
-	}
-
-	// 2. Create satellite posts
-	stmt = tx.StmtContext(ctx, s.CreateSatStmt)
-	for _, sv := range data.Satellites {
-		sv.Sequence = data.Navigation.Sequence // ensure the same sequence number
-		if _, err = stmt.ExecContext(ctx, sv.Args()...); err != nil {
+	nav_stmt := tx.StmtContext(ctx, s.CreateNavStmt)
+	sat_stmt := tx.StmtContext(ctx, s.CreateSatStmt)
+	for i := range data {
+		// 1. Create navigation post
+		if err = execStatement(ctx, nav_stmt, "create_telemetry_nav", data[i].Navigation.Args()...); err != nil {
 			return err
 		}
+
+		// 2. Create satellite posts
+		for _, sv := range data[i].Satellites {
+			sv.Sequence = data[i].Navigation.Sequence // ensure the same sequence number
+			if err = execStatement(ctx, sat_stmt, "create_telemetry_sv", sv.Args()...); err != nil {
+				return err
+			}
+		}
 	}
 
-	return tx.Commit()
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	metrics.IngestEpochs.Add(float64(len(data)))
+
+	// 3. Notify live subscribers
+	for i := range data {
+		s.broker.Publish(data[i])
+	}
+	return nil
 }
 
-// Read telemetry (navigation and satellites) from the table
-func (s *TelemetryService) readTelemetry(
-	ctx context.Context, week uint16, tow float32, do_query bool) ([]Telemetry, error) {
+// Read telemetry (navigation and satellites) from the table, only the selected columns when fields are given
+// (navigation is always read for its sequence, satellites only when any of their fields are selected)
+func (s *TelemetryService) ReadTelemetry(
+	ctx context.Context, week uint16, tow float32, do_query bool, fields *encoder.Fields) ([]Telemetry, error) {
 
-	var n_rows, s_rows *sql.Rows
-	var n_err, s_err error
-	var data []Telemetry
+	nav_fields, ok := fields.Sub("navigation")
+	if !ok {
+		nav_fields, _ = encoder.ParseFields("sequence", navigation.Navigation{})
+	}
+	sat_fields, with_sats := fields.Sub("satellites")
+	nav_cols, nav_args := selectColumns(nav_fields, (&navigation.Navigation{}).Args())
+	sat_cols, sat_args := selectColumns(sat_fields, (&satellite.Satellite{}).Args())
 
+	var data []Telemetry
 	if do_query {
 		// query
-		n_rows, n_err = s.ReadQueryNavStmt.QueryContext(ctx, week, tow)
-		s_rows, s_err = s.ReadQuerySatStmt.QueryContext(ctx, week, tow)
-		if n_err != nil || s_err != nil {
-			return []Telemetry{}, errors.Join(n_err, s_err)
-		}
 		// there are multiple navigation points
-		for n_rows.Next() {
+		index := make(map[uint64]int)
+		err := s.readRows(ctx, s.ReadQueryNavStmt, "read_queried_telemetry_nav", nav_cols, func(rows *sql.Rows) error {
 			var n navigation.Navigation
-			if err := s_rows.Scan(n.Args()...); err != nil {
-				return []Telemetry{}, err
+			if err := rows.Scan(nav_args(n.Args())...); err != nil {
+				return err
 			}
+			index[n.Sequence] = len(data)
 			data = append(data, Telemetry{Navigation: n})
+			return nil
+		}, week, tow)
+		if err != nil || !with_sats {
+			return data, err
 		}
-		// there are multiple satellites
-		i := 0
-		for s_rows.Next() {
+		// there are multiple satellites (matched to their navigation point by sequence)
+		err = s.readRows(ctx, s.ReadQuerySatStmt, "read_queried_telemetry_sv", sat_cols, func(rows *sql.Rows) error {
 			var sv satellite.Satellite
-			if err := s_rows.Scan(sv.Args()...); err != nil {
-				return []Telemetry{}, err
+			if err := rows.Scan(sat_args(sv.Args())...); err != nil {
+				return err
 			}
-			if math.Abs(float64(sv.ToW-data[i].Navigation.ToW)) > 1e-8 {
-				i++
+			if i, ok := index[sv.Sequence]; ok {
+				data[i].Satellites = append(data[i].Satellites, sv)
 			}
-			data[i].Satellites = append(data[i].Satellites, sv)
+			return nil
+		}, week, tow)
+		if err != nil {
+			return []Telemetry{}, err
 		}
 	} else {
 		// latest
 		data = append(data, Telemetry{})
-		n_rows, n_err = s.ReadLatestNavStmt.QueryContext(ctx)
-		s_rows, s_err = s.ReadLatestSatStmt.QueryContext(ctx)
-		if n_err != nil || s_err != nil {
-			return []Telemetry{}, errors.Join(n_err, s_err)
-		}
 		// there is only 1 navigation point
-		if n_rows.Next() {
-			if err := n_rows.Scan(data[0].Navigation.Args()...); err != nil {
-				return []Telemetry{}, err
-			}
+		err := s.readRows(ctx, s.ReadLatestNavStmt, "read_latest_telemetry_nav", nav_cols, func(rows *sql.Rows) error {
+			return rows.Scan(nav_args(data[0].Navigation.Args())...)
+		})
+		if err != nil || !with_sats {
+			return data, err
 		}
 		// there are multiple satellites
-		for s_rows.Next() {
+		err = s.readRows(ctx, s.ReadLatestSatStmt, "read_latest_telemetry_sv", sat_cols, func(rows *sql.Rows) error {
 			var sv satellite.Satellite
-			if err := s_rows.Scan(sv.Args()...); err != nil {
-				return []Telemetry{}, err
+			if err := rows.Scan(sat_args(sv.Args())...); err != nil {
+				return err
 			}
 			data[0].Satellites = append(data[0].Satellites, sv)
+			return nil
+		})
+		if err != nil {
+			return []Telemetry{}, err
 		}
 	}
 
 	return data, nil
 }
 
+// Columns of a projection (nil = every column) and the scan targets among a record's args, the sequence is always selected
+func selectColumns(fields *encoder.Fields, all []any) ([]string, func([]any) []any) {
+	if fields == nil {
+		return nil, func(args []any) []any { return args }
+	}
+	cols, _ := fields.Select(all, "sequence")
+	return cols, func(args []any) []any {
+		_, targets := fields.Select(args, "sequence")
+		return targets
+	}
+}
+
 // Update a telemetry from the table
-func (s *TelemetryService) updateTelemetry(ctx context.Context, data Telemetry, sequence int64) error {
+func (s *TelemetryService) UpdateTelemetry(ctx context.Context, data Telemetry, sequence int64) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -155,14 +230,14 @@ func (s *TelemetryService) updateTelemetry(ctx context.Context, data Telemetry,
 
 	// 1. Update navigation post
 	stmt := tx.StmtContext(ctx, s.UpdateNavStmt)
-	if _, err = stmt.ExecContext(ctx, append(data.Navigation.Args(), sequence)...); err != nil {
+	if err = execStatement(ctx, stmt, "update_telemetry_nav", append(data.Navigation.Args(), sequence)...); err != nil {
 		return err
 	}
 
 	// 2. Update satellite posts
 	stmt = tx.StmtContext(ctx, s.UpdateSatStmt)
 	for _, sv := range data.Satellites {
-		if _, err = stmt.ExecContext(ctx, append(sv.Args(), sequence)...); err != nil {
+		if err = execStatement(ctx, stmt, "update_telemetry_sv", append(sv.Args(), sequence)...); err != nil {
 			return err
 		}
 	}
@@ -171,7 +246,7 @@ func (s *TelemetryService) updateTelemetry(ctx context.Context, data Telemetry,
 }
 
 // Delete a telemetry from the table
-func (s *TelemetryService) deleteTelemetry(ctx context.Context, sequence int64) error {
+func (s *TelemetryService) DeleteTelemetry(ctx context.Context, sequence int64) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -180,24 +255,46 @@ func (s *TelemetryService) deleteTelemetry(ctx context.Context, sequence int64)
 
 	// 1. Delete navigation post
 	stmt := tx.StmtContext(ctx, s.DeleteNavStmt)
-	if _, err = stmt.ExecContext(ctx, sequence); err != nil {
+	if err = execStatement(ctx, stmt, "delete_telemetry_nav", sequence); err != nil {
 		return err
 	}
 
 	// 2. Delete satellite posts
 	stmt = tx.StmtContext(ctx, s.DeleteSatStmt)
-	if _, err = stmt.ExecContext(ctx, sequence); err != nil {
+	if err = execStatement(ctx, stmt, "delete_telemetry_sv", sequence); err != nil {
 		return err
 	}
 
 	return tx.Commit()
 }
 
-// bindStatement
-func bindStatement(db *sql.DB, cmd string, name string) *sql.Stmt {
-	create_stmt, err := db.Prepare(cmd)
+// Execute a prepared statement, recording its latency
+func execStatement(ctx context.Context, stmt *sql.Stmt, name string, args ...any) error {
+	defer metrics.ObserveStatement(name, time.Now())
+	_, err := stmt.ExecContext(ctx, args...)
+	return err
+}
+
+// Query a prepared statement (or its sql limited to some columns) and scan every row, recording its latency
+func (s *TelemetryService) readRows(
+	ctx context.Context, stmt *sql.Stmt, name string, columns []string, scan func(*sql.Rows) error, args ...any) error {
+
+	defer metrics.ObserveStatement(name, time.Now())
+	var rows *sql.Rows
+	var err error
+	if columns == nil {
+		rows, err = stmt.QueryContext(ctx, args...)
+	} else {
+		rows, err = s.db.QueryContext(ctx, encoder.SelectColumns(s.reads[name], columns), args...)
+	}
 	if err != nil {
-		log.Fatalf("Error preparing '%s' statement: %s", name, err.Error())
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
 	}
-	return create_stmt
+	return rows.Err()
 }
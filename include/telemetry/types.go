@@ -1,6 +1,13 @@
 package telemetry
 
 import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sturdivant20/sturdr-api/include/decimate"
 	"github.com/sturdivant20/sturdr-api/include/navigation"
 	"github.com/sturdivant20/sturdr-api/include/satellite"
 )
@@ -9,3 +16,91 @@ type Telemetry struct {
 	Navigation navigation.Navigation `json:"navigation"`
 	Satellites []satellite.Satellite `json:"satellites"`
 }
+
+// Epochs whose n_sat differs from their satellites cannot be framed in binary
+var ErrUnframed = errors.New("binary telemetry needs n_sat to equal the number of satellites")
+
+// Write big-endian binary telemetry (n_sat is the number of satellite records following the navigation record)
+func (data *Telemetry) WriteBinary(w io.Writer) error {
+	if !data.BinaryFramed() {
+		return fmt.Errorf("%w (n_sat %d, %d satellites)", ErrUnframed, data.Navigation.NSat, len(data.Satellites))
+	}
+	if err := binary.Write(w, binary.BigEndian, data.Navigation); err != nil {
+		return err
+	}
+	for _, sv := range data.Satellites {
+		if err := binary.Write(w, binary.BigEndian, sv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Whether n_sat frames the satellites of the epoch
+func (data *Telemetry) BinaryFramed() bool {
+	return int(data.Navigation.NSat) == len(data.Satellites)
+}
+
+// Epochs that binary telemetry can frame, the others are logged and left out
+func framable(ctx context.Context, data []Telemetry) []Telemetry {
+	out := data[:0:0]
+	for i := range data {
+		if !data[i].BinaryFramed() {
+			logger.WarnContext(ctx, "Skipped binary epoch!", "sequence", data[i].Navigation.Sequence,
+				"n_sat", data[i].Navigation.NSat, "satellites", len(data[i].Satellites))
+			continue
+		}
+		out = append(out, data[i])
+	}
+	return out
+}
+
+// Read big-endian binary telemetry written by WriteBinary
+func (data *Telemetry) ReadBinary(r io.Reader) error {
+	if err := binary.Read(r, binary.BigEndian, &data.Navigation); err != nil {
+		return err
+	}
+	data.Satellites = make([]satellite.Satellite, data.Navigation.NSat)
+	return binary.Read(r, binary.BigEndian, data.Satellites)
+}
+
+// Telemetry with the navigation in an output frame ("frame=", EX: Framed[navigation.Ecef])
+type Framed[N any] struct {
+	Navigation N                     `json:"navigation"`
+	Satellites []satellite.Satellite `json:"satellites"`
+}
+
+// Zero record of a frame (for "fields=")
+func frameRecord(f navigation.Frame) any {
+	switch f.Name {
+	case "ecef":
+		return Framed[navigation.Ecef]{}
+	case "enu":
+		return Framed[navigation.Enu]{}
+	}
+	return Telemetry{}
+}
+
+// Convert stored epochs into a frame and downsample them
+func convertFrame(f navigation.Frame, data []Telemetry, opts decimate.Options) (any, error) {
+	switch f.Name {
+	case "ecef":
+		return convert(data, (*navigation.Navigation).Ecef, (*navigation.Ecef).GpsTime, opts)
+	case "enu":
+		to_enu := func(n *navigation.Navigation) navigation.Enu { return n.Enu(f.Origin) }
+		return convert(data, to_enu, (*navigation.Enu).GpsTime, opts)
+	}
+	epoch_time := func(data *Telemetry) float64 { return data.Navigation.GpsTime() }
+	return decimate.Series(data, epoch_time, opts)
+}
+
+func convert[N any](
+	data []Telemetry, to func(*navigation.Navigation) N, at func(*N) float64, opts decimate.Options) ([]Framed[N], error) {
+
+	out := make([]Framed[N], len(data))
+	for i := range data {
+		out[i] = Framed[N]{Navigation: to(&data[i].Navigation), Satellites: data[i].Satellites}
+	}
+	epoch_time := func(data *Framed[N]) float64 { return at(&data.Navigation) }
+	return decimate.Series(out, epoch_time, opts)
+}
@@ -0,0 +1,204 @@
+package truth
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/sturdivant20/sturdr-api/include/decimate"
+	"github.com/sturdivant20/sturdr-api/include/encoder"
+	"github.com/sturdivant20/sturdr-api/include/logging"
+)
+
+var logger = logging.For("truth")
+
+type Handler struct {
+	service Service
+}
+
+func NewHttpHandler(s Service) *Handler {
+	return &Handler{service: s}
+}
+
+// Handle http truth import request, a list of epochs in any request format or an sbet file
+// (EX: "Content-Type: application/x-sbet" with "/truth/import?week=2300")
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	var data []Truth
+	var err error
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "application/x-sbet" {
+		// sbet times are seconds of the week
+		var week uint64
+		if week, err = strconv.ParseUint(r.URL.Query().Get("week"), 10, 16); err != nil {
+			handleError(w, r, errors.New("sbet imports need the gps week as week="), http.StatusBadRequest)
+			return
+		}
+		if data, err = ReadSbet(r.Body, uint16(week)); err != nil {
+			handleError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	} else if err = encoder.Read(r, &data); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+	if err := validate(data); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// store truth using service
+	if err := h.service.CreateTruth(r.Context(), data); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// send success status
+	encoder.WriteText(w, http.StatusOK, fmt.Sprintf("Success (%d epochs)", len(data)))
+}
+
+// Handle http truth read request (EX: "/truth/read?week=2300&tow=0&end_week=2300&end_tow=3600")
+func (h *Handler) Read(w http.ResponseWriter, r *http.Request) {
+	span, err := parseSpan(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// downsample long histories ("every=", "bucket=" or "points=")
+	opts, err := decimate.ParseOptions(r.URL.Query(), "altitude")
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.service.ReadTruth(r.Context(), span)
+	if err != nil {
+		handleError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if data, err = decimate.Series(data, (*Truth).GpsTime, opts); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := encoder.Write(w, r, http.StatusOK, data); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+}
+
+// Handle http truth comparison request (EX: "/truth/compare?week=2300&tow=0&gap=0.5")
+func (h *Handler) Compare(w http.ResponseWriter, r *http.Request) {
+	span, err := parseSpan(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// downsample the per epoch errors, the statistics use every epoch
+	opts, err := decimate.ParseOptions(r.URL.Query(), "horizontal")
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	cmp, err := h.service.CompareTruth(r.Context(), span)
+	if err != nil {
+		handleError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	if cmp.Epochs == 0 {
+		handleError(w, r, fmt.Errorf("no navigation epochs with truth in the requested span (%d unmatched)", cmp.Unmatched),
+			http.StatusNotFound)
+		return
+	}
+	if cmp.Errors, err = decimate.Series(cmp.Errors, (*EpochError).GpsTime, opts); err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := encoder.Write(w, r, http.StatusOK, cmp); err != nil {
+		handleError(w, r, err, encoder.Status(err, http.StatusBadRequest))
+		return
+	}
+}
+
+// Handle http truth clear request (default every epoch)
+func (h *Handler) Clear(w http.ResponseWriter, r *http.Request) {
+	span, err := parseSpan(r)
+	if err != nil {
+		handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	n, err := h.service.DeleteTruth(r.Context(), span)
+	if err != nil {
+		handleError(w, r, err, http.StatusExpectationFailed)
+		return
+	}
+
+	// send success status
+	encoder.WriteText(w, http.StatusOK, fmt.Sprintf("Success (%d epochs)", n))
+}
+
+// Reusable error handler
+func handleError(w http.ResponseWriter, r *http.Request, e error, c int) {
+	logger.WarnContext(r.Context(), "Truth error!", "status", c, "error", e)
+	http.Error(w, e.Error(), c)
+}
+
+// Check the times and positions of imported epochs
+func validate(data []Truth) error {
+	for i := range data {
+		t := &data[i]
+		switch {
+		case !(t.ToW >= 0 && t.ToW <= 604800):
+			return fmt.Errorf("epoch %d: time of week %g is out of range", i, t.ToW)
+		case !(math.Abs(t.Latitude) <= 90) || !(math.Abs(t.Longitude) <= 360):
+			return fmt.Errorf("epoch %d: invalid position %g, %g", i, t.Latitude, t.Longitude)
+		}
+		for _, v := range []float64{t.Altitude, t.Vn, t.Ve, t.Vd, t.Roll, t.Pitch, t.Yaw} {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return fmt.Errorf("epoch %d at %d %g: values must be finite", i, t.Week, t.ToW)
+			}
+		}
+	}
+	return nil
+}
+
+func parseSpan(r *http.Request) (Span, error) {
+	query := r.URL.Query()
+	start_week, err1 := parseWeek(query.Get("week"), 0)
+	start_tow, err2 := parseSeconds(query.Get("tow"), 0)
+	end_week, err3 := parseWeek(query.Get("end_week"), 0xFFFF)
+	end_tow, err4 := parseSeconds(query.Get("end_tow"), 604800)
+	gap, err5 := parseSeconds(query.Get("gap"), 1)
+	if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
+		return Span{}, err
+	}
+	return Span{StartWeek: uint16(start_week), StartToW: start_tow, EndWeek: uint16(end_week), EndToW: end_tow, Gap: gap}, nil
+}
+
+func parseWeek(s string, fallback uint64) (uint64, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid week '%s'", s)
+	}
+	return v, nil
+}
+
+func parseSeconds(s string, fallback float64) (float64, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("invalid time '%s'", s)
+	}
+	return v, nil
+}
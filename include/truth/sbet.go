@@ -0,0 +1,52 @@
+package truth
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+)
+
+// Sbet (smoothed best estimate of trajectory) records are 17 little-endian float64: time of week [s], latitude,
+// longitude [rad], altitude [m], x, y, z velocity [m/s], roll, pitch, heading, wander angle [rad], x, y, z
+// acceleration [m/s^2] and x, y, z angular rate [rad/s]
+const sbetFields = 17
+
+// Decode sbet records whose times are seconds of the given gps week (times past the end of the week roll over into
+// the next weeks). The x/y velocities are in the wander frame and are rotated to north/east, z is down.
+func ReadSbet(r io.Reader, week uint16) ([]Truth, error) {
+	var items []Truth
+	var rec [sbetFields]float64
+	for {
+		err := binary.Read(r, binary.LittleEndian, &rec)
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		} else if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("sbet: truncated record %d", len(items))
+		} else if err != nil {
+			return nil, err
+		}
+		if !(rec[0] >= 0) {
+			return nil, fmt.Errorf("sbet: record %d has an invalid time %g", len(items), rec[0])
+		}
+
+		weeks := math.Floor(rec[0] / 604800)
+		sin_w, cos_w := math.Sincos(rec[10])
+		items = append(items, Truth{
+			Week:      week + uint16(weeks),
+			ToW:       rec[0] - weeks*604800,
+			Latitude:  rec[1] * geodesy.Rad2Deg,
+			Longitude: rec[2] * geodesy.Rad2Deg,
+			Altitude:  rec[3],
+			Vn:        rec[4]*cos_w - rec[5]*sin_w,
+			Ve:        rec[4]*sin_w + rec[5]*cos_w,
+			Vd:        rec[6],
+			Roll:      rec[7] * geodesy.Rad2Deg,
+			Pitch:     rec[8] * geodesy.Rad2Deg,
+			Yaw:       math.Mod(rec[9]*geodesy.Rad2Deg+360, 360),
+		})
+	}
+}
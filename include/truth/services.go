@@ -0,0 +1,273 @@
+package truth
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sturdivant20/sturdr-api/include/analysis"
+	"github.com/sturdivant20/sturdr-api/include/geodesy"
+	"github.com/sturdivant20/sturdr-api/include/metrics"
+)
+
+// Time span of a truth request
+type Span struct {
+	StartWeek uint16
+	StartToW  float64
+	EndWeek   uint16
+	EndToW    float64
+	Gap       float64 // longest truth gap interpolated across [s]
+}
+
+type Service interface {
+	CreateTruth(ctx context.Context, data []Truth) error
+	ReadTruth(ctx context.Context, span Span) ([]Truth, error)
+	DeleteTruth(ctx context.Context, span Span) (int64, error)
+	CompareTruth(ctx context.Context, span Span) (Comparison, error)
+}
+
+type TruthService struct {
+	db                 *sql.DB
+	CreateStmt         *sql.Stmt
+	ReadSpanStmt       *sql.Stmt
+	DeleteSpanStmt     *sql.Stmt
+	ReadNavigationStmt *sql.Stmt
+}
+
+// Initialize/create the truth table
+func NewTruthService(db *sql.DB, sql_fname string) Service {
+	// --- read sql commands from file ---
+	data, err := os.ReadFile(sql_fname)
+	if err != nil {
+		logger.Error("Error reading SQL file!", "file", sql_fname, "error", err)
+		os.Exit(1)
+	}
+	cmd := strings.Split(string(data), ";")
+
+	// --- create table ---
+	statement := bindStatement(db, strings.TrimSpace(cmd[0]), "make_truth_table")
+	statement.Exec()
+	statement = bindStatement(db, cmd[1], "create_truth_index")
+	statement.Exec()
+	logger.Info("Created database table ...", "table", "truth")
+
+	// --- bind statements ---
+	return &TruthService{
+		db:                 db,
+		CreateStmt:         bindStatement(db, strings.TrimSpace(cmd[2]), "create_truth"),
+		ReadSpanStmt:       bindStatement(db, strings.TrimSpace(cmd[3]), "read_truth_span"),
+		DeleteSpanStmt:     bindStatement(db, strings.TrimSpace(cmd[4]), "delete_truth_span"),
+		ReadNavigationStmt: bindStatement(db, strings.TrimSpace(cmd[5]), "read_truth_navigation_span")}
+}
+
+// Store truth epochs in a single transaction (an epoch at an existing time replaces it)
+func (s *TruthService) CreateTruth(ctx context.Context, data []Truth) error {
+	defer metrics.ObserveStatement("create_truth", time.Now())
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := tx.StmtContext(ctx, s.CreateStmt)
+	for i := range data {
+		if _, err = stmt.ExecContext(ctx, data[i].Args()...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Read the truth epochs of a span
+func (s *TruthService) ReadTruth(ctx context.Context, span Span) ([]Truth, error) {
+	defer metrics.ObserveStatement("read_truth_span", time.Now())
+	rows, err := s.ReadSpanStmt.QueryContext(ctx, span.StartWeek, span.StartToW, span.EndWeek, span.EndToW)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []Truth{}
+	for rows.Next() {
+		var t Truth
+		if err := rows.Scan(t.Args()...); err != nil {
+			return nil, err
+		}
+		items = append(items, t)
+	}
+	return items, rows.Err()
+}
+
+// Delete the truth epochs of a span, returning the number deleted
+func (s *TruthService) DeleteTruth(ctx context.Context, span Span) (int64, error) {
+	defer metrics.ObserveStatement("delete_truth_span", time.Now())
+	res, err := s.DeleteSpanStmt.ExecContext(ctx, span.StartWeek, span.StartToW, span.EndWeek, span.EndToW)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Compare the navigation epochs of a span with the truth linearly interpolated to their times (both tables are
+// streamed in time order side by side)
+func (s *TruthService) CompareTruth(ctx context.Context, span Span) (Comparison, error) {
+	defer metrics.ObserveStatement("read_truth_navigation_span", time.Now())
+	nav_rows, err := s.ReadNavigationStmt.QueryContext(ctx, span.StartWeek, span.StartToW, span.EndWeek, span.EndToW)
+	if err != nil {
+		return Comparison{}, err
+	}
+	defer nav_rows.Close()
+
+	// truth is read a gap beyond the span, so the first and last epochs are bracketed
+	start_week, start_tow := weekTime(gpsTime(span.StartWeek, span.StartToW) - span.Gap)
+	end_week, end_tow := weekTime(gpsTime(span.EndWeek, span.EndToW) + span.Gap)
+	truth_rows, err := s.ReadSpanStmt.QueryContext(ctx, start_week, start_tow, end_week, end_tow)
+	if err != nil {
+		return Comparison{}, err
+	}
+	defer truth_rows.Close()
+
+	// prev and next bracket the current navigation epoch
+	var prev, next *Truth
+	advance := func() error {
+		prev, next = next, nil
+		if truth_rows.Next() {
+			next = &Truth{}
+			return truth_rows.Scan(next.Args()...)
+		}
+		return truth_rows.Err()
+	}
+	if err := advance(); err != nil {
+		return Comparison{}, err
+	}
+
+	cmp := Comparison{Errors: []EpochError{}}
+	var pos, vel []analysis.ENU
+	for nav_rows.Next() {
+		var n Truth // same columns as the truth table
+		if err := nav_rows.Scan(n.Args()...); err != nil {
+			return Comparison{}, err
+		}
+		t := n.GpsTime()
+		for next != nil && next.GpsTime() < t {
+			if err := advance(); err != nil {
+				return Comparison{}, err
+			}
+		}
+
+		// 1. truth at the epoch
+		var ref Truth
+		switch {
+		case next != nil && next.GpsTime() == t:
+			ref = *next
+		case prev != nil && next != nil && next.GpsTime()-prev.GpsTime() <= span.Gap:
+			ref = interpolate(prev, next, (t-prev.GpsTime())/(next.GpsTime()-prev.GpsTime()))
+		default:
+			cmp.Unmatched++
+			continue
+		}
+
+		// 2. navigation minus truth
+		e := epochError(&n, &ref)
+		if cmp.Epochs == 0 {
+			cmp.StartWeek, cmp.StartToW = n.Week, n.ToW
+		}
+		cmp.EndWeek, cmp.EndToW = n.Week, n.ToW
+		cmp.Epochs++
+		cmp.Errors = append(cmp.Errors, e)
+		pos = append(pos, e.Position)
+		vel = append(vel, e.Velocity)
+	}
+	if err := nav_rows.Err(); err != nil {
+		return Comparison{}, err
+	}
+
+	// 3. statistics of every compared epoch
+	cmp.Position = analysis.PositionAccuracy(pos)
+	cmp.Velocity = VelocityStats{
+		East:       summarize(vel, func(v analysis.ENU) float64 { return v.East }),
+		North:      summarize(vel, func(v analysis.ENU) float64 { return v.North }),
+		Up:         summarize(vel, func(v analysis.ENU) float64 { return v.Up }),
+		Horizontal: summarize(vel, func(v analysis.ENU) float64 { return math.Hypot(v.East, v.North) }),
+	}
+	cmp.Attitude = AttitudeStats{
+		Roll:  summarize(cmp.Errors, func(e EpochError) float64 { return e.Roll }),
+		Pitch: summarize(cmp.Errors, func(e EpochError) float64 { return e.Pitch }),
+		Yaw:   summarize(cmp.Errors, func(e EpochError) float64 { return e.Yaw }),
+	}
+	return cmp, nil
+}
+
+// Truth between two epochs (a fraction f of the way from a to b), angles interpolate across the wrap
+func interpolate(a *Truth, b *Truth, f float64) Truth {
+	lerp := func(x, y float64) float64 { return x + f*(y-x) }
+	angle := func(x, y float64) float64 { return wrap180(x + f*wrap180(y-x)) }
+	week, tow := weekTime(lerp(a.GpsTime(), b.GpsTime()))
+	return Truth{
+		Week:      week,
+		ToW:       tow,
+		Latitude:  lerp(a.Latitude, b.Latitude),
+		Longitude: angle(a.Longitude, b.Longitude),
+		Altitude:  lerp(a.Altitude, b.Altitude),
+		Vn:        lerp(a.Vn, b.Vn),
+		Ve:        lerp(a.Ve, b.Ve),
+		Vd:        lerp(a.Vd, b.Vd),
+		Roll:      angle(a.Roll, b.Roll),
+		Pitch:     lerp(a.Pitch, b.Pitch),
+		Yaw:       math.Mod(angle(a.Yaw, b.Yaw)+360, 360),
+	}
+}
+
+// Navigation minus truth, the position in the local level frame of the truth
+func epochError(n *Truth, ref *Truth) EpochError {
+	origin := geodesy.NewOrigin([3]float64{ref.Latitude * geodesy.Deg2Rad, ref.Longitude * geodesy.Deg2Rad, ref.Altitude})
+	enu := origin.Lla2Enu([3]float64{n.Latitude * geodesy.Deg2Rad, n.Longitude * geodesy.Deg2Rad, n.Altitude})
+	return EpochError{
+		Week:       n.Week,
+		ToW:        n.ToW,
+		Position:   analysis.ENU{East: enu[0], North: enu[1], Up: enu[2]},
+		Horizontal: math.Hypot(enu[0], enu[1]),
+		Velocity:   analysis.ENU{East: n.Ve - ref.Ve, North: n.Vn - ref.Vn, Up: ref.Vd - n.Vd},
+		Roll:       wrap180(n.Roll - ref.Roll),
+		Pitch:      n.Pitch - ref.Pitch,
+		Yaw:        wrap180(n.Yaw - ref.Yaw),
+	}
+}
+
+func summarize[T any](items []T, value func(T) float64) analysis.Summary {
+	x := make([]float64, len(items))
+	for i := range items {
+		x[i] = value(items[i])
+	}
+	return analysis.Summarize(x)
+}
+
+// Angle wrapped to [-180, 180) [deg]
+func wrap180(a float64) float64 {
+	return a - 360*math.Floor((a+180)/360)
+}
+
+// Seconds since the gps epoch
+func gpsTime(week uint16, tow float64) float64 {
+	return float64(week)*604800 + tow
+}
+
+// Gps week and time of week of seconds since the gps epoch (clamped to the valid weeks)
+func weekTime(t float64) (uint16, float64) {
+	t = min(max(t, 0), 0xFFFF*604800+604800)
+	week := min(math.Floor(t/604800), 0xFFFF)
+	return uint16(week), t - week*604800
+}
+
+// bindStatement
+func bindStatement(db *sql.DB, cmd string, name string) *sql.Stmt {
+	create_stmt, err := db.Prepare(cmd)
+	if err != nil {
+		logger.Error("Error preparing statement!", "statement", name, "error", err)
+		os.Exit(1)
+	}
+	return create_stmt
+}
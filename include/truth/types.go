@@ -0,0 +1,85 @@
+package truth
+
+import "github.com/sturdivant20/sturdr-api/include/analysis"
+
+// Reference trajectory epoch (EX: from a tactical-grade ins)
+type Truth struct {
+	Week      uint16  `json:"week"`
+	ToW       float64 `json:"tow"`
+	Latitude  float64 `json:"latitude"` // [deg]
+	Longitude float64 `json:"longitude"`
+	Altitude  float64 `json:"altitude"` // [m]
+	Vn        float64 `json:"vn"`       // [m/s]
+	Ve        float64 `json:"ve"`
+	Vd        float64 `json:"vd"`
+	Roll      float64 `json:"roll"` // [deg]
+	Pitch     float64 `json:"pitch"`
+	Yaw       float64 `json:"yaw"`
+}
+
+func (t *Truth) Args() []any {
+	return []any{
+		&t.Week,
+		&t.ToW,
+		&t.Latitude,
+		&t.Longitude,
+		&t.Altitude,
+		&t.Vn,
+		&t.Ve,
+		&t.Vd,
+		&t.Roll,
+		&t.Pitch,
+		&t.Yaw,
+	}
+}
+
+// Seconds since the gps epoch
+func (t *Truth) GpsTime() float64 {
+	return gpsTime(t.Week, t.ToW)
+}
+
+// Navigation errors against the truth trajectory
+type Comparison struct {
+	StartWeek uint16            `json:"start_week"` // first and last compared epoch
+	StartToW  float64           `json:"start_tow"`
+	EndWeek   uint16            `json:"end_week"`
+	EndToW    float64           `json:"end_tow"`
+	Epochs    uint64            `json:"epochs"`    // navigation epochs compared
+	Unmatched uint64            `json:"unmatched"` // navigation epochs without truth around them (within "gap=")
+	Position  analysis.Accuracy `json:"position"`  // in the local level frame of the truth [m]
+	Velocity  VelocityStats     `json:"velocity"`
+	Attitude  AttitudeStats     `json:"attitude"`
+	Errors    []EpochError      `json:"errors"` // per epoch (downsampled when asked)
+}
+
+// Velocity error statistics [m/s]
+type VelocityStats struct {
+	East       analysis.Summary `json:"east"`
+	North      analysis.Summary `json:"north"`
+	Up         analysis.Summary `json:"up"`
+	Horizontal analysis.Summary `json:"horizontal"`
+}
+
+// Attitude error statistics [deg]
+type AttitudeStats struct {
+	Roll  analysis.Summary `json:"roll"`
+	Pitch analysis.Summary `json:"pitch"`
+	Yaw   analysis.Summary `json:"yaw"`
+}
+
+// Navigation minus interpolated truth at one epoch
+type EpochError struct {
+	Week       uint16       `json:"week"`
+	ToW        float64      `json:"tow"`
+	Position   analysis.ENU `json:"position"` // [m]
+	Horizontal float64      `json:"horizontal"`
+	Velocity   analysis.ENU `json:"velocity"` // [m/s]
+	Roll       float64      `json:"roll"`     // [deg]
+	Pitch      float64      `json:"pitch"`
+	Yaw        float64      `json:"yaw"`
+}
+
+// Seconds since the gps epoch
+func (e *EpochError) GpsTime() float64 {
+	return gpsTime(e.Week, e.ToW)
+}
@@ -2,14 +2,16 @@ package main
 
 import (
 	"context"
-	"log"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/sturdivant20/sturdr-api/include/api"
+	"github.com/sturdivant20/sturdr-api/include/logging"
 )
 
+var logger = logging.For("main")
+
 func main() {
 	var app api.Application
 
@@ -18,13 +20,15 @@ func main() {
 	defer stop()
 
 	// initialize server
-	log.Println("Parsing TOML settings ...")
+	logger.Info("Parsing TOML settings ...")
 	if err := app.Init("./config/settings.toml"); err != nil {
-		log.Fatalf("Failed to initialize database!")
+		logger.Error("Failed to initialize database!", "error", err)
+		os.Exit(1)
 	}
 
 	// run server
 	if err := app.Run(ctx, app.Mount()); err != nil {
-		log.Fatalf("Failed to start server! %s", err.Error())
+		logger.Error("Failed to start server!", "error", err)
+		os.Exit(1)
 	}
 }